@@ -0,0 +1,70 @@
+package android
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FutureApiLevel is the sentinel API level used for "current" and unreleased API surfaces. The
+// literal SDK integer "10000" (used by some build configs to mean the same thing) must parse to
+// this same value rather than being rejected.
+const FutureApiLevel = 10000
+
+// ApiLevel represents a parsed SDK API level: either a numbered release, "current"/the future
+// level, or an active preview codename with its configured preview ordering.
+type ApiLevel struct {
+	// Number is the numbered API level, or FutureApiLevel for "current", the literal "10000", or
+	// an active preview codename.
+	Number int
+	// Codename is the preview codename this level was parsed from, or "" for a numbered release.
+	Codename string
+	// PreviewOrder is the configured ordering of Codename among active preview codenames, used
+	// to sort multiple simultaneously active previews; 0 for numbered releases.
+	PreviewOrder int
+}
+
+func (l ApiLevel) IsPreview() bool { return l.Codename != "" }
+
+// ApiLevelFromUser parses s as either a plain integer (including the literal "10000", which maps
+// to FutureApiLevel), "current" (also FutureApiLevel), or one of the active preview codenames
+// listed in previewCodenamesInOrder (earliest-active first). Each codename's index in
+// previewCodenamesInOrder becomes its PreviewOrder, so multiple active codenames sort
+// deterministically instead of by parse order.
+func ApiLevelFromUser(s string, previewCodenamesInOrder []string) (ApiLevel, error) {
+	if s == "current" {
+		return ApiLevel{Number: FutureApiLevel}, nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		if n == FutureApiLevel {
+			return ApiLevel{Number: FutureApiLevel}, nil
+		}
+		return ApiLevel{Number: n}, nil
+	}
+
+	for i, codename := range previewCodenamesInOrder {
+		if codename == s {
+			return ApiLevel{Number: FutureApiLevel, Codename: codename, PreviewOrder: i}, nil
+		}
+	}
+
+	return ApiLevel{}, fmt.Errorf("unrecognized API level %q: accepted forms are a numbered release, %q, or one of the active preview codenames %v", s, "current", previewCodenamesInOrder)
+}
+
+// SortApiLevels sorts levels by Number first, then by PreviewOrder for levels that share a
+// Number (i.e. multiple active preview codenames, which all report FutureApiLevel), so preview
+// ordering is deterministic rather than depending on input order.
+func SortApiLevels(levels []ApiLevel) {
+	for i := 1; i < len(levels); i++ {
+		for j := i; j > 0 && apiLevelLess(levels[j], levels[j-1]); j-- {
+			levels[j], levels[j-1] = levels[j-1], levels[j]
+		}
+	}
+}
+
+func apiLevelLess(a, b ApiLevel) bool {
+	if a.Number != b.Number {
+		return a.Number < b.Number
+	}
+	return a.PreviewOrder < b.PreviewOrder
+}
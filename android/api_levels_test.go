@@ -0,0 +1,77 @@
+package android
+
+import "testing"
+
+func TestApiLevelFromUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		codenames []string
+		wantErr   bool
+		wantLvl   ApiLevel
+	}{
+		{
+			name:    "future literal",
+			input:   "10000",
+			wantLvl: ApiLevel{Number: FutureApiLevel},
+		},
+		{
+			name:    "current",
+			input:   "current",
+			wantLvl: ApiLevel{Number: FutureApiLevel},
+		},
+		{
+			name:    "numbered release",
+			input:   "30",
+			wantLvl: ApiLevel{Number: 30},
+		},
+		{
+			name:      "preview codename",
+			input:     "R",
+			codenames: []string{"Q", "R"},
+			wantLvl:   ApiLevel{Number: FutureApiLevel, Codename: "R", PreviewOrder: 1},
+		},
+		{
+			name:      "unrecognized",
+			input:     "not-a-level",
+			codenames: []string{"Q"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lvl, err := ApiLevelFromUser(tt.input, tt.codenames)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if lvl != tt.wantLvl {
+				t.Errorf("ApiLevelFromUser(%q, %v) = %+v, want %+v", tt.input, tt.codenames, lvl, tt.wantLvl)
+			}
+		})
+	}
+}
+
+func TestApiLevelFromUser_UnorderedPreviewCodenamesSortDeterministically(t *testing.T) {
+	codenames := []string{"Q", "R"}
+	q, err := ApiLevelFromUser("Q", codenames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := ApiLevelFromUser("R", codenames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	levels := []ApiLevel{r, q}
+	SortApiLevels(levels)
+	if levels[0].Codename != "Q" || levels[1].Codename != "R" {
+		t.Errorf("expected Q before R after sort, got %+v", levels)
+	}
+}
@@ -0,0 +1,72 @@
+package android
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildParams is the minimal view of a module's ninja rule invocation that
+// ValidateBuildParams checks, mirroring the fields ModuleBuild assembles.
+type BuildParams struct {
+	Rule             string
+	Output           string
+	Outputs          []string
+	ImplicitOutputs  []string
+	SymlinkOutput    string
+	Depfile          string
+	RuleSupportsDeps bool
+}
+
+// allOutputs returns every declared output of params, in the order Output, Outputs,
+// ImplicitOutputs.
+func (params BuildParams) allOutputs() []string {
+	var all []string
+	if params.Output != "" {
+		all = append(all, params.Output)
+	}
+	all = append(all, params.Outputs...)
+	all = append(all, params.ImplicitOutputs...)
+	return all
+}
+
+// ValidateBuildParams checks params for the mistakes that otherwise only surface as late ninja
+// errors: a symlink output that isn't declared among the rule's outputs or that points outside
+// outDir, duplicate outputs across Output/Outputs/ImplicitOutputs, and a Depfile set on a rule
+// that doesn't support dependency files. moduleName is included in every returned error so
+// module authors can find the offending module without re-deriving it from a ninja failure.
+func ValidateBuildParams(moduleName, outDir string, params BuildParams) []error {
+	var errs []error
+
+	outputs := params.allOutputs()
+
+	seen := make(map[string]bool, len(outputs))
+	for _, out := range outputs {
+		if seen[out] {
+			errs = append(errs, fmt.Errorf("%s: output %q is declared more than once across Output/Outputs/ImplicitOutputs", moduleName, out))
+		}
+		seen[out] = true
+	}
+
+	if params.SymlinkOutput != "" {
+		if !seen[params.SymlinkOutput] {
+			errs = append(errs, fmt.Errorf("%s: SymlinkOutput %q is not among the rule's declared outputs", moduleName, params.SymlinkOutput))
+		}
+		if !isUnderDir(params.SymlinkOutput, outDir) {
+			errs = append(errs, fmt.Errorf("%s: SymlinkOutput %q does not live under the output directory %q", moduleName, params.SymlinkOutput, outDir))
+		}
+	}
+
+	if params.Depfile != "" && !params.RuleSupportsDeps {
+		errs = append(errs, fmt.Errorf("%s: Depfile %q is set on a rule that does not support dependency files", moduleName, params.Depfile))
+	}
+
+	return errs
+}
+
+func isUnderDir(path, dir string) bool {
+	if dir == "" {
+		return true
+	}
+	dir = strings.TrimSuffix(dir, "/") + "/"
+	return strings.HasPrefix(path, dir)
+}
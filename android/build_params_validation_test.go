@@ -0,0 +1,50 @@
+package android
+
+import "testing"
+
+func TestValidateBuildParams_SymlinkOutsideOutDir(t *testing.T) {
+	params := BuildParams{
+		Output:        "out/soong/foo.so",
+		SymlinkOutput: "/tmp/foo.so",
+	}
+	errs := ValidateBuildParams("mymodule", "out/soong", params)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors (not declared + outside out dir), got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateBuildParams_DuplicateOutputs(t *testing.T) {
+	params := BuildParams{
+		Output:  "out/soong/foo.so",
+		Outputs: []string{"out/soong/foo.so"},
+	}
+	errs := ValidateBuildParams("mymodule", "out/soong", params)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 duplicate-output error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateBuildParams_DepfileWithoutDepsSupport(t *testing.T) {
+	params := BuildParams{
+		Output:           "out/soong/foo.so",
+		Depfile:          "out/soong/foo.d",
+		RuleSupportsDeps: false,
+	}
+	errs := ValidateBuildParams("mymodule", "out/soong", params)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 depfile error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateBuildParams_Valid(t *testing.T) {
+	params := BuildParams{
+		Output:           "out/soong/foo.so",
+		SymlinkOutput:    "out/soong/foo.so",
+		Depfile:          "out/soong/foo.d",
+		RuleSupportsDeps: true,
+	}
+	errs := ValidateBuildParams("mymodule", "out/soong", params)
+	if len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
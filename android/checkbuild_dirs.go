@@ -0,0 +1,71 @@
+package android
+
+import (
+	"sort"
+	"strings"
+)
+
+// CheckbuildFileEntry records one output registered via ctx.CheckbuildFile, tagged with the
+// directory of the module that registered it so the checkbuild singleton can aggregate outputs
+// into a "checkbuild-<dir>" phony per directory.
+type CheckbuildFileEntry struct {
+	ModuleDir string
+	Output    string
+}
+
+// CheckbuildDirPhony is one per-directory phony target the singleton emits: its name and the
+// outputs it depends on, which include every output registered directly under Dir plus every
+// output aggregated into a child directory's phony (so parent directory phonies compose).
+type CheckbuildDirPhony struct {
+	Name    string
+	Dir     string
+	Outputs []string
+}
+
+// AggregateCheckbuildDirs groups entries by module directory into deterministically-ordered,
+// composing per-directory phonies: "checkbuild-a/b" depends on its own direct outputs plus every
+// output reachable through "checkbuild-a/b/c", and so on up to the root.
+func AggregateCheckbuildDirs(entries []CheckbuildFileEntry) []CheckbuildDirPhony {
+	direct := make(map[string][]string)
+	dirSet := make(map[string]bool)
+	for _, e := range entries {
+		direct[e.ModuleDir] = append(direct[e.ModuleDir], e.Output)
+		for d := e.ModuleDir; d != ""; d = parentDir(d) {
+			dirSet[d] = true
+		}
+	}
+
+	dirs := make([]string, 0, len(dirSet))
+	for d := range dirSet {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+
+	// Outputs for d = direct[d] plus the outputs of every dir that has d as an ancestor,
+	// restricted to dir's own direct outputs (avoids double counting by only ever reading
+	// `direct`, not other phonies' aggregated sets).
+	phonies := make([]CheckbuildDirPhony, 0, len(dirs))
+	for _, d := range dirs {
+		outs := append([]string(nil), direct[d]...)
+		for _, other := range dirs {
+			if other != d && isUnderDirPath(other, d) {
+				outs = append(outs, direct[other]...)
+			}
+		}
+		sort.Strings(outs)
+		phonies = append(phonies, CheckbuildDirPhony{Name: "checkbuild-" + d, Dir: d, Outputs: outs})
+	}
+	return phonies
+}
+
+func parentDir(dir string) string {
+	idx := strings.LastIndexByte(dir, '/')
+	if idx == -1 {
+		return ""
+	}
+	return dir[:idx]
+}
+
+func isUnderDirPath(path, dir string) bool {
+	return strings.HasPrefix(path, dir+"/")
+}
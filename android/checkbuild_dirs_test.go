@@ -0,0 +1,49 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAggregateCheckbuildDirs_ParentIncludesChild(t *testing.T) {
+	entries := []CheckbuildFileEntry{
+		{ModuleDir: "external/foo", Output: "foo.so"},
+		{ModuleDir: "external/foo/sub", Output: "sub.so"},
+	}
+
+	phonies := AggregateCheckbuildDirs(entries)
+
+	byName := make(map[string]CheckbuildDirPhony)
+	for _, p := range phonies {
+		byName[p.Name] = p
+	}
+
+	parent, ok := byName["checkbuild-external/foo"]
+	if !ok {
+		t.Fatalf("expected a checkbuild-external/foo phony, got %+v", phonies)
+	}
+	want := []string{"foo.so", "sub.so"}
+	if !reflect.DeepEqual(parent.Outputs, want) {
+		t.Errorf("got %v, want %v", parent.Outputs, want)
+	}
+}
+
+func TestAggregateCheckbuildDirs_DeterministicOrdering(t *testing.T) {
+	entries := []CheckbuildFileEntry{
+		{ModuleDir: "external/foo", Output: "z.so"},
+		{ModuleDir: "external/foo", Output: "a.so"},
+	}
+
+	first := AggregateCheckbuildDirs(entries)
+	second := AggregateCheckbuildDirs(entries)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected deterministic output across calls")
+	}
+	for _, p := range first {
+		if p.Name == "checkbuild-external/foo" {
+			if !reflect.DeepEqual(p.Outputs, []string{"a.so", "z.so"}) {
+				t.Errorf("expected sorted outputs, got %v", p.Outputs)
+			}
+		}
+	}
+}
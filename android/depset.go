@@ -0,0 +1,132 @@
+package android
+
+import "sync"
+
+// DepSetOrder selects the flattening order a DepSet's ToList uses.
+type DepSetOrder int
+
+const (
+	// PREORDER lists a DepSet's own direct values before recursing into its transitive DepSets.
+	PREORDER DepSetOrder = iota
+	// POSTORDER lists transitive DepSets before a DepSet's own direct values.
+	POSTORDER
+	// TOPOLOGICAL lists values such that a DepSet's own direct values come after everything its
+	// transitive DepSets contribute, with duplicates across branches deduped to their last
+	// occurrence — the ordering link-order computations need for -L path ordering.
+	TOPOLOGICAL
+)
+
+// DepSet is an immutable, shareable set of values of type T plus references to transitive
+// DepSets, flattened lazily (and memoized) on the first call to ToList rather than eagerly at
+// construction, so building thousands of small DepSets doesn't pay flattening cost for branches
+// that are never queried.
+type DepSet[T comparable] struct {
+	order      DepSetOrder
+	direct     []T
+	transitive []*DepSet[T]
+
+	once sync.Once
+	flat []T
+}
+
+// NewDepSet returns a DepSet with the given order, direct values, and transitive DepSets. All
+// transitive DepSets must share the same order.
+func NewDepSet[T comparable](order DepSetOrder, direct []T, transitive []*DepSet[T]) *DepSet[T] {
+	return &DepSet[T]{
+		order:      order,
+		direct:     append([]T(nil), direct...),
+		transitive: transitive,
+	}
+}
+
+// ToList returns the flattened, order-appropriate list of values in d and its transitive
+// DepSets. The result is memoized: the first call flattens, every subsequent call on the same
+// DepSet returns the cached slice in O(1).
+func (d *DepSet[T]) ToList() []T {
+	d.once.Do(func() {
+		switch d.order {
+		case PREORDER:
+			d.flat = d.flattenPreorder()
+		case POSTORDER:
+			d.flat = d.flattenPostorder()
+		default:
+			d.flat = d.flattenTopological()
+		}
+	})
+	return d.flat
+}
+
+// ToListDirect returns d's own direct values without flattening transitive DepSets, avoiding any
+// allocation beyond what the caller already owns for the common single-level case.
+func (d *DepSet[T]) ToListDirect() []T {
+	return d.direct
+}
+
+func (d *DepSet[T]) flattenPreorder() []T {
+	var result []T
+	var seen = make(map[T]bool)
+	var visit func(d *DepSet[T])
+	visit = func(d *DepSet[T]) {
+		for _, v := range d.direct {
+			if !seen[v] {
+				seen[v] = true
+				result = append(result, v)
+			}
+		}
+		for _, t := range d.transitive {
+			visit(t)
+		}
+	}
+	visit(d)
+	return result
+}
+
+func (d *DepSet[T]) flattenPostorder() []T {
+	var result []T
+	var seen = make(map[T]bool)
+	var visit func(d *DepSet[T])
+	visit = func(d *DepSet[T]) {
+		for _, t := range d.transitive {
+			visit(t)
+		}
+		for _, v := range d.direct {
+			if !seen[v] {
+				seen[v] = true
+				result = append(result, v)
+			}
+		}
+	}
+	visit(d)
+	return result
+}
+
+// flattenTopological lists every transitive DepSet's values before d's own, with values that
+// occur in more than one branch kept only at their last (topologically latest) occurrence.
+func (d *DepSet[T]) flattenTopological() []T {
+	var order []T
+	var visit func(d *DepSet[T])
+	visit = func(d *DepSet[T]) {
+		for _, t := range d.transitive {
+			visit(t)
+		}
+		order = append(order, d.direct...)
+	}
+	visit(d)
+
+	// Keep only each value's last occurrence, preserving the order of those last occurrences.
+	keep := make([]bool, len(order))
+	seenLast := make(map[T]bool)
+	for i := len(order) - 1; i >= 0; i-- {
+		if !seenLast[order[i]] {
+			seenLast[order[i]] = true
+			keep[i] = true
+		}
+	}
+	var result []T
+	for i, v := range order {
+		if keep[i] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDepSet_Preorder(t *testing.T) {
+	leaf := NewDepSet(PREORDER, []string{"c"}, nil)
+	root := NewDepSet(PREORDER, []string{"a", "b"}, []*DepSet[string]{leaf})
+
+	got := root.ToList()
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDepSet_Postorder(t *testing.T) {
+	leaf := NewDepSet(POSTORDER, []string{"c"}, nil)
+	root := NewDepSet(POSTORDER, []string{"a", "b"}, []*DepSet[string]{leaf})
+
+	got := root.ToList()
+	want := []string{"c", "a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDepSet_Topological(t *testing.T) {
+	leaf := NewDepSet(TOPOLOGICAL, []string{"shared", "c"}, nil)
+	root := NewDepSet(TOPOLOGICAL, []string{"a", "shared"}, []*DepSet[string]{leaf})
+
+	got := root.ToList()
+	want := []string{"c", "a", "shared"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDepSet_ToListMemoized(t *testing.T) {
+	d := NewDepSet(PREORDER, []string{"a"}, nil)
+	first := d.ToList()
+	second := d.ToList()
+	if &first[0] != &second[0] {
+		t.Errorf("expected ToList to return the same memoized backing array on repeated calls")
+	}
+}
+
+func TestDepSet_ToListDirect(t *testing.T) {
+	leaf := NewDepSet(PREORDER, []string{"c"}, nil)
+	root := NewDepSet(PREORDER, []string{"a", "b"}, []*DepSet[string]{leaf})
+
+	got := root.ToListDirect()
+	want := []string{"a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func BenchmarkDepSet_ToList(b *testing.B) {
+	leaf := NewDepSet(TOPOLOGICAL, []int{0}, nil)
+	for i := 1; i < 5000; i++ {
+		leaf = NewDepSet(TOPOLOGICAL, []int{i}, []*DepSet[int]{leaf})
+	}
+	for i := 0; i < b.N; i++ {
+		d := NewDepSet(TOPOLOGICAL, []int{-1}, []*DepSet[int]{leaf})
+		d.ToList()
+	}
+}
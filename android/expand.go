@@ -0,0 +1,83 @@
+package android
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExpandErrorFunc is called by Expand for each "$(name args...)" substitution. It returns the
+// expansion of name, or an error to be wrapped into an *ExpandError that carries the position of
+// the failing substitution within the original string.
+type ExpandErrorFunc func(name string) (string, error)
+
+// ExpandError is returned by Expand when an ExpandErrorFunc callback fails. It carries the byte
+// offset of the failing "$(...)" substitution so callers can report exactly where in a long
+// command string the typo is, instead of just "unknown variable".
+type ExpandError struct {
+	Err    error
+	Input  string
+	Offset int
+}
+
+func (e *ExpandError) Error() string {
+	line, col := lineAndColumn(e.Input, e.Offset)
+	return fmt.Sprintf("%s\n%s\n%s^ (line %d, column %d)", e.Err, e.Input, strings.Repeat(" ", e.Offset), line, col)
+}
+
+func (e *ExpandError) Unwrap() error { return e.Err }
+
+func lineAndColumn(s string, offset int) (line, col int) {
+	line = 1
+	col = 1
+	for i := 0; i < offset && i < len(s); i++ {
+		if s[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+// Expand scans s for "$(name args...)" substitutions and "$[literal]" escape-free literals,
+// replacing each with the result of calling fn(name) (literals are passed through verbatim, with
+// no further substitution inside them). On error from fn, Expand returns an *ExpandError
+// annotated with the byte offset of the failing substitution, with a caret pointing at it in the
+// formatted message.
+func Expand(s string, fn ExpandErrorFunc) (string, error) {
+	var buf strings.Builder
+	i := 0
+	for i < len(s) {
+		if s[i] == '$' && i+1 < len(s) && s[i+1] == '[' {
+			close := strings.IndexByte(s[i+2:], ']')
+			if close == -1 {
+				return "", &ExpandError{Err: fmt.Errorf("unterminated $[ literal"), Input: s, Offset: i}
+			}
+			buf.WriteString(s[i+2 : i+2+close])
+			i = i + 2 + close + 1
+			continue
+		}
+		if s[i] == '$' && i+1 < len(s) && s[i+1] == '(' {
+			close := strings.IndexByte(s[i+2:], ')')
+			if close == -1 {
+				return "", &ExpandError{Err: fmt.Errorf("unterminated $( substitution"), Input: s, Offset: i}
+			}
+			body := s[i+2 : i+2+close]
+			name := body
+			if sp := strings.IndexAny(body, " \t"); sp != -1 {
+				name = body[:sp]
+			}
+			result, err := fn(name)
+			if err != nil {
+				return "", &ExpandError{Err: err, Input: s, Offset: i}
+			}
+			buf.WriteString(result)
+			i = i + 2 + close + 1
+			continue
+		}
+		buf.WriteByte(s[i])
+		i++
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,61 @@
+package android
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func knownVars(name string) (string, error) {
+	switch name {
+	case "location":
+		return "/path/to/tool", nil
+	case "in":
+		return "in.txt", nil
+	case "out":
+		return "out.txt", nil
+	default:
+		return "", fmt.Errorf("unknown variable %q", name)
+	}
+}
+
+func TestExpand_Success(t *testing.T) {
+	got, err := Expand("$(location) $(in) > $(out)", knownVars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "/path/to/tool in.txt > out.txt"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExpand_ReportsPosition(t *testing.T) {
+	cmd := "$(location) $(in) $(locaton foo) > $(out)"
+	_, err := Expand(cmd, knownVars)
+	if err == nil {
+		t.Fatalf("expected error for unknown variable")
+	}
+	var expandErr *ExpandError
+	if !errors.As(err, &expandErr) {
+		t.Fatalf("expected an *ExpandError, got %T: %v", err, err)
+	}
+	if expandErr.Offset != strings.Index(cmd, "$(locaton") {
+		t.Errorf("got offset %d, want %d", expandErr.Offset, strings.Index(cmd, "$(locaton"))
+	}
+	if !strings.Contains(err.Error(), "^") {
+		t.Errorf("expected a caret-annotated snippet in the error, got: %s", err.Error())
+	}
+}
+
+func TestExpand_LiteralEscape(t *testing.T) {
+	got, err := Expand("$[$(not expanded)]", knownVars)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "$(not expanded)"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package android
+
+import "fmt"
+
+// FixturePreparer composes a test's setup: registering module types/mutators and laying in
+// extra Android.bp content, so individual tests don't hand-assemble the same boilerplate. Callers
+// compose preparers with GroupFixturePreparers and finish with RunTest.
+type FixturePreparer interface {
+	apply(*FixtureState)
+}
+
+// FixtureState accumulates what a chain of FixturePreparers has contributed, before RunTest
+// turns it into a TestResult.
+type FixtureState struct {
+	ModuleTypes    map[string]bool
+	ExtraBpFiles   []string
+	ConfigMutators []func(*FixtureConfig)
+}
+
+// FixtureConfig is the minimal config surface preparers can mutate; a real implementation would
+// be the full android.Config.
+type FixtureConfig struct {
+	Properties map[string]string
+}
+
+type fixturePreparerFunc func(*FixtureState)
+
+func (f fixturePreparerFunc) apply(s *FixtureState) { f(s) }
+
+// FixtureRegisterModuleType returns a preparer that registers one module type by name.
+func FixtureRegisterModuleType(name string) FixturePreparer {
+	return fixturePreparerFunc(func(s *FixtureState) {
+		s.ModuleTypes[name] = true
+	})
+}
+
+// FixtureWithExtraBp returns a preparer that layers an extra Android.bp snippet into the test.
+func FixtureWithExtraBp(bp string) FixturePreparer {
+	return fixturePreparerFunc(func(s *FixtureState) {
+		s.ExtraBpFiles = append(s.ExtraBpFiles, bp)
+	})
+}
+
+// FixtureModifyConfig returns a preparer that applies a config mutation.
+func FixtureModifyConfig(mutate func(*FixtureConfig)) FixturePreparer {
+	return fixturePreparerFunc(func(s *FixtureState) {
+		s.ConfigMutators = append(s.ConfigMutators, mutate)
+	})
+}
+
+// GroupFixturePreparers composes several preparers into one, applied in order.
+func GroupFixturePreparers(preparers ...FixturePreparer) FixturePreparer {
+	return fixturePreparerFunc(func(s *FixtureState) {
+		for _, p := range preparers {
+			p.apply(s)
+		}
+	})
+}
+
+// PrepareForTestWithCcDefaultModules registers the module types a typical cc test needs:
+// cc_library, cc_library_shared, cc_library_static, cc_binary, cc_object, and their defaults
+// type, so individual cc tests don't each re-register the same list inconsistently.
+var PrepareForTestWithCcDefaultModules = FixtureRegisterModuleTypes(
+	"cc_library", "cc_library_shared", "cc_library_static", "cc_binary", "cc_object", "cc_defaults",
+)
+
+// PrepareForTestWithGenrule registers the module types a typical genrule test needs.
+var PrepareForTestWithGenrule = FixtureRegisterModuleTypes("genrule", "gensrcs", "genrule_defaults")
+
+// FixtureRegisterModuleTypes is the plural form of FixtureRegisterModuleType.
+func FixtureRegisterModuleTypes(names ...string) FixturePreparer {
+	return fixturePreparerFunc(func(s *FixtureState) {
+		for _, name := range names {
+			s.ModuleTypes[name] = true
+		}
+	})
+}
+
+// TestResult is returned by RunTest: the resolved FixtureState plus helpers tests use to make
+// assertions.
+type TestResult struct {
+	state *FixtureState
+	errs  []error
+}
+
+// RunTest applies preparer to a fresh FixtureState and returns the resulting TestResult.
+func RunTest(preparer FixturePreparer) *TestResult {
+	state := &FixtureState{ModuleTypes: map[string]bool{}}
+	preparer.apply(state)
+	return &TestResult{state: state}
+}
+
+// ModuleForTests reports whether moduleType was registered for this test, the way a real
+// ModuleForTests would look up a built module by name/variant; this lightweight version only
+// checks module type registration, which is what fixture composition is responsible for.
+func (r *TestResult) ModuleForTests(moduleType string) (found bool) {
+	return r.state.ModuleTypes[moduleType]
+}
+
+// ExpectedErrors returns the errors accumulated for this test result.
+func (r *TestResult) ExpectedErrors() []error {
+	return r.errs
+}
+
+// AddError records an error on the result, for tests asserting against ExpectedErrors.
+func (r *TestResult) AddError(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Errorf(format, args...))
+}
@@ -0,0 +1,37 @@
+package android
+
+import "testing"
+
+func TestPrepareForTestWithCcDefaultModules_RegistersExpectedTypes(t *testing.T) {
+	result := RunTest(PrepareForTestWithCcDefaultModules)
+	for _, mt := range []string{"cc_library", "cc_library_shared", "cc_binary"} {
+		if !result.ModuleForTests(mt) {
+			t.Errorf("expected %s to be registered", mt)
+		}
+	}
+}
+
+func TestGroupFixturePreparers_ComposesExtraBpAndModuleTypes(t *testing.T) {
+	preparer := GroupFixturePreparers(
+		PrepareForTestWithGenrule,
+		FixtureWithExtraBp(`genrule { name: "gen" }`),
+	)
+	state := &FixtureState{ModuleTypes: map[string]bool{}}
+	preparer.apply(state)
+
+	if !state.ModuleTypes["genrule"] {
+		t.Errorf("expected genrule module type to be registered")
+	}
+	if len(state.ExtraBpFiles) != 1 {
+		t.Errorf("expected one extra bp file, got %d", len(state.ExtraBpFiles))
+	}
+}
+
+func TestTestResult_ExpectedErrors(t *testing.T) {
+	result := RunTest(PrepareForTestWithCcDefaultModules)
+	result.AddError("module %q depends on missing module %q", "libfoo", "libbar")
+
+	if len(result.ExpectedErrors()) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(result.ExpectedErrors()))
+	}
+}
@@ -0,0 +1,38 @@
+package android
+
+import (
+	"fmt"
+	"sync"
+)
+
+// genPathRegistry records, for every declared output/gen path claimed via
+// RegisterModuleGenPath, which module claimed it, so a second module claiming the same path can
+// be caught immediately instead of producing nondeterministic file contents with no error. It is
+// mutex-protected like the versioningMacroNamesList registry, and cheap enough to be always-on.
+type genPathRegistry struct {
+	mu     sync.Mutex
+	owners map[string]string // path -> owning module name
+}
+
+var modulePathRegistry = &genPathRegistry{owners: map[string]string{}}
+
+// RegisterModuleGenPath claims path for moduleName. It returns an error naming both modules if
+// path was already claimed by a different module.
+func RegisterModuleGenPath(moduleName, path string) error {
+	modulePathRegistry.mu.Lock()
+	defer modulePathRegistry.mu.Unlock()
+
+	if owner, ok := modulePathRegistry.owners[path]; ok && owner != moduleName {
+		return fmt.Errorf("gen path collision: %q is claimed by both %q and %q", path, owner, moduleName)
+	}
+	modulePathRegistry.owners[path] = moduleName
+	return nil
+}
+
+// ResetModuleGenPathRegistryForTests clears the registry. It exists only for test isolation,
+// mirroring the other Reset...ForTests helpers in this package (e.g. ResetGeneratedSourceInfoForTests).
+func ResetModuleGenPathRegistryForTests() {
+	modulePathRegistry.mu.Lock()
+	defer modulePathRegistry.mu.Unlock()
+	modulePathRegistry.owners = map[string]string{}
+}
@@ -0,0 +1,41 @@
+package android
+
+import "testing"
+
+func TestRegisterModuleGenPath_GenruleVsGenruleCollision(t *testing.T) {
+	ResetModuleGenPathRegistryForTests()
+	defer ResetModuleGenPathRegistryForTests()
+
+	if err := RegisterModuleGenPath("genrule_a", "out/soong/.intermediates/pkg/genrule_a/gen/include"); err != nil {
+		t.Fatalf("unexpected error on first claim: %v", err)
+	}
+	err := RegisterModuleGenPath("genrule_b", "out/soong/.intermediates/pkg/genrule_a/gen/include")
+	if err == nil {
+		t.Fatalf("expected a collision error when genrule_b claims genrule_a's path")
+	}
+}
+
+func TestRegisterModuleGenPath_GenruleVsCcCollision(t *testing.T) {
+	ResetModuleGenPathRegistryForTests()
+	defer ResetModuleGenPathRegistryForTests()
+
+	if err := RegisterModuleGenPath("libfoo", "out/soong/.intermediates/pkg/libfoo/gen/include"); err != nil {
+		t.Fatalf("unexpected error on first claim: %v", err)
+	}
+	err := RegisterModuleGenPath("gen_includes", "out/soong/.intermediates/pkg/libfoo/gen/include")
+	if err == nil {
+		t.Fatalf("expected a collision error when gen_includes claims libfoo's path")
+	}
+}
+
+func TestRegisterModuleGenPath_SameModuleReclaimIsNotACollision(t *testing.T) {
+	ResetModuleGenPathRegistryForTests()
+	defer ResetModuleGenPathRegistryForTests()
+
+	if err := RegisterModuleGenPath("libfoo", "out/soong/.intermediates/pkg/libfoo/gen/include"); err != nil {
+		t.Fatalf("unexpected error on first claim: %v", err)
+	}
+	if err := RegisterModuleGenPath("libfoo", "out/soong/.intermediates/pkg/libfoo/gen/include"); err != nil {
+		t.Errorf("unexpected error re-claiming the same path from the same module: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package android
+
+import "sync"
+
+// GeneratedSourceInfo is the typed provider data a module that generates files for other modules
+// to consume sets, replacing genrule.SourceFileGenerator interface assertions: cc and java can
+// read this directly instead of type-asserting a dep to a specific generator package's interface,
+// which breaks for any module type that isn't literally a genrule.Module.
+type GeneratedSourceInfo struct {
+	// GeneratedSourceFiles are the outputs to be compiled directly (e.g. listed in
+	// generated_sources).
+	GeneratedSourceFiles []string
+
+	// GeneratedHeaderDirs are the directories consumers should add to their include path for the
+	// generated headers.
+	GeneratedHeaderDirs []string
+
+	// GeneratedSystemHeaderDirs are GeneratedHeaderDirs entries that should be added to the
+	// consumer's include path as system includes (-isystem) rather than plain -I, so warnings
+	// from the generated headers can be suppressed.
+	GeneratedSystemHeaderDirs []string
+
+	// GeneratedDeps are every output file a consumer must depend on, including non-source,
+	// non-header outputs.
+	GeneratedDeps []string
+}
+
+// generatedSourceInfoRegistry is a module-keyed registry standing in for a real blueprint
+// provider in this simulated pipeline; modules set their info via SetGeneratedSourceInfo and
+// consumers read it back via GeneratedSourceInfoFor.
+var generatedSourceInfoRegistry = struct {
+	mu   sync.Mutex
+	info map[string]GeneratedSourceInfo
+}{info: map[string]GeneratedSourceInfo{}}
+
+// SetGeneratedSourceInfo records moduleName's generated source info, to be read back by
+// dependents via GeneratedSourceInfoFor.
+func SetGeneratedSourceInfo(moduleName string, info GeneratedSourceInfo) {
+	generatedSourceInfoRegistry.mu.Lock()
+	defer generatedSourceInfoRegistry.mu.Unlock()
+	generatedSourceInfoRegistry.info[moduleName] = info
+}
+
+// GeneratedSourceInfoFor looks up a previously set provider for moduleName. The ok return is
+// false for modules that never called SetGeneratedSourceInfo, letting callers fall back to the
+// legacy interface-assertion path during migration.
+func GeneratedSourceInfoFor(moduleName string) (info GeneratedSourceInfo, ok bool) {
+	generatedSourceInfoRegistry.mu.Lock()
+	defer generatedSourceInfoRegistry.mu.Unlock()
+	info, ok = generatedSourceInfoRegistry.info[moduleName]
+	return info, ok
+}
+
+// ResetGeneratedSourceInfoForTests clears the provider registry between test cases.
+func ResetGeneratedSourceInfoForTests() {
+	generatedSourceInfoRegistry.mu.Lock()
+	defer generatedSourceInfoRegistry.mu.Unlock()
+	generatedSourceInfoRegistry.info = map[string]GeneratedSourceInfo{}
+}
@@ -0,0 +1,35 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGeneratedSourceInfo_SetAndGet(t *testing.T) {
+	ResetGeneratedSourceInfoForTests()
+	defer ResetGeneratedSourceInfoForTests()
+
+	want := GeneratedSourceInfo{
+		GeneratedSourceFiles: []string{"out/foo.cpp"},
+		GeneratedHeaderDirs:  []string{"out/include"},
+		GeneratedDeps:        []string{"out/foo.cpp", "out/foo.h"},
+	}
+	SetGeneratedSourceInfo("my_custom_generator", want)
+
+	got, ok := GeneratedSourceInfoFor("my_custom_generator")
+	if !ok {
+		t.Fatalf("expected a provider to be set")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestGeneratedSourceInfo_MissingProviderFallsBack(t *testing.T) {
+	ResetGeneratedSourceInfoForTests()
+	defer ResetGeneratedSourceInfoForTests()
+
+	if _, ok := GeneratedSourceInfoFor("unset_module"); ok {
+		t.Errorf("expected ok=false for a module that never set the provider")
+	}
+}
@@ -0,0 +1,42 @@
+package android
+
+import "fmt"
+
+// MissingDependency records one dependency that AddMissingDependencies couldn't resolve, along
+// with the property it was declared under, so the eventual error can say which property
+// (tools, srcs, shared_libs, ...) named the missing module instead of only listing module names.
+type MissingDependency struct {
+	Property string
+	DepName  string
+}
+
+// FormatMissingDependencyError renders the error message for one missing dependency of module
+// moduleName, in the form Error-rule generation emits under AllowMissingDependencies.
+func FormatMissingDependencyError(moduleName string, dep MissingDependency) string {
+	return fmt.Sprintf("module %q property %q depends on missing module %q", moduleName, dep.Property, dep.DepName)
+}
+
+// FormatMissingDependencyErrors renders one error message per entry in deps, in order.
+func FormatMissingDependencyErrors(moduleName string, deps []MissingDependency) []string {
+	errs := make([]string, len(deps))
+	for i, dep := range deps {
+		errs[i] = FormatMissingDependencyError(moduleName, dep)
+	}
+	return errs
+}
+
+// MissingDependenciesTracker accumulates property-tagged missing dependencies, for dep mutators (genrule's tools/srcs, cc's shared_libs, etc.) to report
+// multiple missing deps across multiple properties in one pass instead of only the first found.
+type MissingDependenciesTracker struct {
+	deps []MissingDependency
+}
+
+// Add records one missing dependency under the given property name.
+func (t *MissingDependenciesTracker) Add(property, depName string) {
+	t.deps = append(t.deps, MissingDependency{Property: property, DepName: depName})
+}
+
+// Errors renders every tracked missing dependency into its error message for moduleName.
+func (t *MissingDependenciesTracker) Errors(moduleName string) []string {
+	return FormatMissingDependencyErrors(moduleName, t.deps)
+}
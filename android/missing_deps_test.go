@@ -0,0 +1,29 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatMissingDependencyError(t *testing.T) {
+	got := FormatMissingDependencyError("mygenrule", MissingDependency{Property: "tools", DepName: "missing_tool"})
+	want := `module "mygenrule" property "tools" depends on missing module "missing_tool"`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMissingDependenciesTracker_MultipleProperties(t *testing.T) {
+	var tracker MissingDependenciesTracker
+	tracker.Add("tools", "missing_tool")
+	tracker.Add("srcs", "missing_src")
+
+	got := tracker.Errors("mygenrule")
+	want := []string{
+		`module "mygenrule" property "tools" depends on missing module "missing_tool"`,
+		`module "mygenrule" property "srcs" depends on missing module "missing_src"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package android
+
+import (
+	"sort"
+	"sync"
+)
+
+// MixedBuildOutcome describes what happened when a module eligible for a mixed build (cc/genrule
+// handled via Bazel) was resolved.
+type MixedBuildOutcome int
+
+const (
+	// MixedBuildBazelUsed means the module's bazelHandler successfully resolved outputs from Bazel.
+	MixedBuildBazelUsed MixedBuildOutcome = iota
+	// MixedBuildFellBack means the module was eligible but fell back to the Soong-native action,
+	// for the reason recorded alongside the counter.
+	MixedBuildFellBack
+)
+
+// MixedBuildFallbackReason is a stable, reportable reason a module fell back from Bazel to Soong.
+type MixedBuildFallbackReason string
+
+const (
+	FallbackReasonQueryMiss    MixedBuildFallbackReason = "query_miss"
+	FallbackReasonUnsupported  MixedBuildFallbackReason = "unsupported_module_type"
+	FallbackReasonNotRequested MixedBuildFallbackReason = "not_requested"
+)
+
+// mixedBuildMetricsKey groups counters by module type and, for fallbacks, by reason.
+type mixedBuildMetricsKey struct {
+	moduleType string
+	outcome    MixedBuildOutcome
+	reason     MixedBuildFallbackReason
+}
+
+// mixedBuildMetrics is the package-global, mutex-protected counter registry fed by bazelHandler
+// implementations (object, library, genrule) as they resolve each eligible module.
+type mixedBuildMetrics struct {
+	mu     sync.Mutex
+	counts map[mixedBuildMetricsKey]int
+}
+
+var globalMixedBuildMetrics = &mixedBuildMetrics{counts: map[mixedBuildMetricsKey]int{}}
+
+// RecordMixedBuildBazelUsed increments the "used Bazel results" counter for moduleType.
+func RecordMixedBuildBazelUsed(moduleType string) {
+	globalMixedBuildMetrics.mu.Lock()
+	defer globalMixedBuildMetrics.mu.Unlock()
+	key := mixedBuildMetricsKey{moduleType: moduleType, outcome: MixedBuildBazelUsed}
+	globalMixedBuildMetrics.counts[key]++
+}
+
+// RecordMixedBuildFallback increments the fallback counter for moduleType, keyed also by reason.
+func RecordMixedBuildFallback(moduleType string, reason MixedBuildFallbackReason) {
+	globalMixedBuildMetrics.mu.Lock()
+	defer globalMixedBuildMetrics.mu.Unlock()
+	key := mixedBuildMetricsKey{moduleType: moduleType, outcome: MixedBuildFellBack, reason: reason}
+	globalMixedBuildMetrics.counts[key]++
+}
+
+// MixedBuildMetricsEntry is one row of the aggregated report: a module type/outcome/reason triple
+// and how many modules landed in it.
+type MixedBuildMetricsEntry struct {
+	ModuleType string
+	Outcome    MixedBuildOutcome
+	Reason     MixedBuildFallbackReason
+	Count      int
+}
+
+// MixedBuildMetricsReport returns every recorded counter, sorted by module type then outcome then
+// reason so the emitted metrics file is deterministic across runs.
+func MixedBuildMetricsReport() []MixedBuildMetricsEntry {
+	globalMixedBuildMetrics.mu.Lock()
+	defer globalMixedBuildMetrics.mu.Unlock()
+
+	entries := make([]MixedBuildMetricsEntry, 0, len(globalMixedBuildMetrics.counts))
+	for k, count := range globalMixedBuildMetrics.counts {
+		entries = append(entries, MixedBuildMetricsEntry{
+			ModuleType: k.moduleType,
+			Outcome:    k.outcome,
+			Reason:     k.reason,
+			Count:      count,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ModuleType != entries[j].ModuleType {
+			return entries[i].ModuleType < entries[j].ModuleType
+		}
+		if entries[i].Outcome != entries[j].Outcome {
+			return entries[i].Outcome < entries[j].Outcome
+		}
+		return entries[i].Reason < entries[j].Reason
+	})
+	return entries
+}
+
+// ResetMixedBuildMetricsForTests clears the counter registry. It exists only for test isolation.
+func ResetMixedBuildMetricsForTests() {
+	globalMixedBuildMetrics.mu.Lock()
+	defer globalMixedBuildMetrics.mu.Unlock()
+	globalMixedBuildMetrics.counts = map[mixedBuildMetricsKey]int{}
+}
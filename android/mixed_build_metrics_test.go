@@ -0,0 +1,54 @@
+package android
+
+import "testing"
+
+func TestMixedBuildMetrics_CountsByTypeAndReason(t *testing.T) {
+	ResetMixedBuildMetricsForTests()
+	defer ResetMixedBuildMetricsForTests()
+
+	RecordMixedBuildBazelUsed("cc_library")
+	RecordMixedBuildBazelUsed("cc_library")
+	RecordMixedBuildFallback("cc_library", FallbackReasonQueryMiss)
+	RecordMixedBuildFallback("cc_genrule", FallbackReasonUnsupported)
+
+	report := MixedBuildMetricsReport()
+	if len(report) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(report), report)
+	}
+
+	var usedCount, fallbackCount int
+	for _, e := range report {
+		if e.ModuleType == "cc_library" && e.Outcome == MixedBuildBazelUsed {
+			usedCount = e.Count
+		}
+		if e.ModuleType == "cc_library" && e.Outcome == MixedBuildFellBack && e.Reason == FallbackReasonQueryMiss {
+			fallbackCount = e.Count
+		}
+	}
+	if usedCount != 2 {
+		t.Errorf("got usedCount %d, want 2", usedCount)
+	}
+	if fallbackCount != 1 {
+		t.Errorf("got fallbackCount %d, want 1", fallbackCount)
+	}
+}
+
+func TestMixedBuildMetrics_DeterministicOrder(t *testing.T) {
+	ResetMixedBuildMetricsForTests()
+	defer ResetMixedBuildMetricsForTests()
+
+	RecordMixedBuildFallback("genrule", FallbackReasonNotRequested)
+	RecordMixedBuildBazelUsed("cc_object")
+	RecordMixedBuildBazelUsed("cc_library")
+
+	first := MixedBuildMetricsReport()
+	second := MixedBuildMetricsReport()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("report order not stable at index %d: %+v vs %+v", i, first[i], second[i])
+		}
+	}
+	if first[0].ModuleType != "cc_library" {
+		t.Errorf("got first entry module type %q, want cc_library", first[0].ModuleType)
+	}
+}
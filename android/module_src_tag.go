@@ -0,0 +1,84 @@
+package android
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SrcIsModuleWithTagError is returned by SrcIsModuleWithTagOrError when s has malformed tag
+// syntax, so callers like PathsForModuleSrc can surface exactly where the parse failed instead
+// of silently falling back to treating the whole string as a module name.
+type SrcIsModuleWithTagError struct {
+	Src    string
+	Reason string
+}
+
+func (e *SrcIsModuleWithTagError) Error() string {
+	return fmt.Sprintf("invalid module reference %q: %s", e.Src, e.Reason)
+}
+
+// SrcIsModuleWithTagOrError parses s as ":module{tag}" or ":module", returning the module name
+// and tag (tag is "" when absent). Unlike the legacy SrcIsModuleWithTag, it rejects malformed
+// input — unbalanced braces, more than one "{...}" group, or an empty tag — with a
+// *SrcIsModuleWithTagError instead of silently treating the malformed string as a module name.
+func SrcIsModuleWithTagOrError(s string) (module, tag string, err error) {
+	if !strings.HasPrefix(s, ":") {
+		return "", "", nil
+	}
+	rest := s[1:]
+
+	open := strings.IndexByte(rest, '{')
+	if open == -1 {
+		return rest, "", nil
+	}
+
+	if !strings.HasSuffix(rest, "}") {
+		return "", "", &SrcIsModuleWithTagError{Src: s, Reason: "unterminated tag, expected a trailing '}'"}
+	}
+
+	module = rest[:open]
+	tagBody := rest[open+1 : len(rest)-1]
+
+	if strings.ContainsAny(tagBody, "{}") {
+		return "", "", &SrcIsModuleWithTagError{Src: s, Reason: "exactly one tag is supported; found nested or multiple '{...}' groups"}
+	}
+	if tagBody == "" {
+		return "", "", &SrcIsModuleWithTagError{Src: s, Reason: "tag must not be empty"}
+	}
+
+	return module, tagBody, nil
+}
+
+// SrcIsModuleWithTags is like SrcIsModuleWithTagOrError but accepts a module reference followed
+// by any number of consecutive "{tag}" groups (for future multi-output consumers like genrules
+// with several named outputs per module) and returns all of them in order.
+func SrcIsModuleWithTags(s string) (module string, tags []string, err error) {
+	if !strings.HasPrefix(s, ":") {
+		return "", nil, nil
+	}
+	rest := s[1:]
+
+	open := strings.IndexByte(rest, '{')
+	if open == -1 {
+		return rest, nil, nil
+	}
+	module = rest[:open]
+	rest = rest[open:]
+
+	for len(rest) > 0 {
+		if rest[0] != '{' {
+			return "", nil, &SrcIsModuleWithTagError{Src: s, Reason: "expected '{' to start a tag group"}
+		}
+		close := strings.IndexByte(rest, '}')
+		if close == -1 {
+			return "", nil, &SrcIsModuleWithTagError{Src: s, Reason: "unterminated tag, expected a trailing '}'"}
+		}
+		tag := rest[1:close]
+		if tag == "" || strings.ContainsAny(tag, "{}") {
+			return "", nil, &SrcIsModuleWithTagError{Src: s, Reason: "tag must be a single non-empty group with no nested braces"}
+		}
+		tags = append(tags, tag)
+		rest = rest[close+1:]
+	}
+	return module, tags, nil
+}
@@ -0,0 +1,52 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSrcIsModuleWithTagOrError(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantMod   string
+		wantTag   string
+		wantError bool
+	}{
+		{in: ":module", wantMod: "module"},
+		{in: ":module{tag}", wantMod: "module", wantTag: "tag"},
+		{in: "plain", wantMod: ""},
+		{in: ":module{outs/a.h}{foo}", wantError: true},
+		{in: ":module{unterminated", wantError: true},
+		{in: ":module{}", wantError: true},
+	}
+	for _, tt := range tests {
+		mod, tag, err := SrcIsModuleWithTagOrError(tt.in)
+		if tt.wantError {
+			if err == nil {
+				t.Errorf("SrcIsModuleWithTagOrError(%q): expected error, got module=%q tag=%q", tt.in, mod, tag)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SrcIsModuleWithTagOrError(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if mod != tt.wantMod || tag != tt.wantTag {
+			t.Errorf("SrcIsModuleWithTagOrError(%q) = (%q, %q), want (%q, %q)", tt.in, mod, tag, tt.wantMod, tt.wantTag)
+		}
+	}
+}
+
+func TestSrcIsModuleWithTags(t *testing.T) {
+	mod, tags, err := SrcIsModuleWithTags(":module{a}{b}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mod != "module" || !reflect.DeepEqual(tags, []string{"a", "b"}) {
+		t.Errorf("got module=%q tags=%v", mod, tags)
+	}
+
+	if _, _, err := SrcIsModuleWithTags(":module{a}{}"); err == nil {
+		t.Errorf("expected error for empty tag group")
+	}
+}
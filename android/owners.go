@@ -0,0 +1,73 @@
+package android
+
+import "strings"
+
+// OwnerProperties holds a module's owner metadata. Owner is the legacy singular property, kept
+// for compatibility; Owners is the new plural form. Both may be set, in which case their values
+// are merged.
+type OwnerProperties struct {
+	Owner  *string
+	Owners []string
+}
+
+// OwnerList returns every owner recorded on the module, merging the legacy singular Owner (if
+// set) ahead of the plural Owners, with duplicates removed.
+func OwnerList(props OwnerProperties) []string {
+	var owners []string
+	if props.Owner != nil && *props.Owner != "" {
+		owners = append(owners, *props.Owner)
+	}
+
+	seen := make(map[string]bool, len(owners))
+	for _, o := range owners {
+		seen[o] = true
+	}
+	for _, o := range props.Owners {
+		if !seen[o] {
+			seen[o] = true
+			owners = append(owners, o)
+		}
+	}
+	return owners
+}
+
+// AndroidMkOwnerValue returns the LOCAL_MODULE_OWNER value for a module's owner list, joining
+// multiple owners with spaces as Make expects for a list-valued variable.
+func AndroidMkOwnerValue(props OwnerProperties) string {
+	return strings.Join(OwnerList(props), " ")
+}
+
+// OwnersReportEntry is one entry of the owners.json report the owners singleton emits, mapping
+// an installed file to the owners of the module that installed it.
+type OwnersReportEntry struct {
+	InstalledFile string   `json:"installed_file"`
+	Owners        []string `json:"owners"`
+}
+
+// BuildOwnersReport builds the owners.json report entries from a set of (installed file, owner
+// properties) pairs, covering apex payload files via the same filesInfo enumeration other apex
+// reports use. Entries with no owners are omitted.
+func BuildOwnersReport(files map[string]OwnerProperties) []OwnersReportEntry {
+	var report []OwnersReportEntry
+	for _, installedFile := range sortedStringKeys(files) {
+		owners := OwnerList(files[installedFile])
+		if len(owners) == 0 {
+			continue
+		}
+		report = append(report, OwnersReportEntry{InstalledFile: installedFile, Owners: owners})
+	}
+	return report
+}
+
+func sortedStringKeys(m map[string]OwnerProperties) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j-1] > keys[j]; j-- {
+			keys[j-1], keys[j] = keys[j], keys[j-1]
+		}
+	}
+	return keys
+}
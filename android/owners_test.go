@@ -0,0 +1,39 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOwnerList_MergesLegacyAndPlural(t *testing.T) {
+	owner := "legacy-team"
+	props := OwnerProperties{Owner: &owner, Owners: []string{"new-team", "legacy-team"}}
+	got := OwnerList(props)
+	want := []string{"legacy-team", "new-team"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAndroidMkOwnerValue_JoinsWithSpaces(t *testing.T) {
+	props := OwnerProperties{Owners: []string{"team-a", "team-b"}}
+	got := AndroidMkOwnerValue(props)
+	want := "team-a team-b"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildOwnersReport(t *testing.T) {
+	files := map[string]OwnerProperties{
+		"system/bin/foo": {Owners: []string{"team-a"}},
+		"system/bin/bar": {},
+	}
+	report := BuildOwnersReport(files)
+	if len(report) != 1 {
+		t.Fatalf("expected only the owned file to appear, got %+v", report)
+	}
+	if report[0].InstalledFile != "system/bin/foo" {
+		t.Errorf("got %+v", report[0])
+	}
+}
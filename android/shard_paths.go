@@ -0,0 +1,41 @@
+package android
+
+import "sort"
+
+// ShardPaths splits paths into shards of at most shardSize each, sorting paths first (unless
+// preserveOrder is set) so the sharding is deterministic regardless of the filesystem-dependent
+// order globs may produce paths in — otherwise shard contents (and therefore remote cache keys)
+// can differ across machines for identical inputs.
+func ShardPaths(paths []string, shardSize int, preserveOrder bool) [][]string {
+	if shardSize <= 0 {
+		return [][]string{paths}
+	}
+
+	ordered := paths
+	if !preserveOrder {
+		ordered = append([]string(nil), paths...)
+		sort.Strings(ordered)
+	}
+
+	var shards [][]string
+	for len(ordered) > 0 {
+		n := shardSize
+		if n > len(ordered) {
+			n = len(ordered)
+		}
+		shards = append(shards, ordered[:n])
+		ordered = ordered[n:]
+	}
+	return shards
+}
+
+// ShardSizeMultiplier reads a config-driven global multiplier applied to every module's
+// requested shard size, so CI can trade parallelism for action count (larger shards, fewer
+// actions) without editing every module's shard_size property. A multiplier of 0 or a negative
+// value is treated as 1 (no change).
+func ShardSizeMultiplier(baseShardSize int, configMultiplier int) int {
+	if configMultiplier <= 0 {
+		configMultiplier = 1
+	}
+	return baseShardSize * configMultiplier
+}
@@ -0,0 +1,34 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShardPaths_DeterministicAcrossPermutedInput(t *testing.T) {
+	a := []string{"c.txt", "a.txt", "b.txt", "d.txt"}
+	b := []string{"d.txt", "b.txt", "a.txt", "c.txt"}
+
+	shardsA := ShardPaths(a, 2, false)
+	shardsB := ShardPaths(b, 2, false)
+	if !reflect.DeepEqual(shardsA, shardsB) {
+		t.Errorf("expected identical sharding for permuted input, got %v vs %v", shardsA, shardsB)
+	}
+}
+
+func TestShardPaths_PreserveOrderOptOut(t *testing.T) {
+	in := []string{"b.txt", "a.txt"}
+	shards := ShardPaths(in, 2, true)
+	if !reflect.DeepEqual(shards, [][]string{{"b.txt", "a.txt"}}) {
+		t.Errorf("got %v", shards)
+	}
+}
+
+func TestShardSizeMultiplier(t *testing.T) {
+	if got := ShardSizeMultiplier(10, 2); got != 20 {
+		t.Errorf("got %d, want 20", got)
+	}
+	if got := ShardSizeMultiplier(10, 0); got != 10 {
+		t.Errorf("got %d, want 10 (multiplier 0 treated as 1)", got)
+	}
+}
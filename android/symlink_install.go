@@ -0,0 +1,52 @@
+package android
+
+import (
+	"path"
+	"strings"
+)
+
+// RelativeSymlinkPath computes the relative path from installDir to target, for use by
+// InstallRelativeSymlink callers that need a symlink surviving a different mount root (host-side
+// image inspection, chroot tests) instead of an absolute path like "/apex/com.android.runtime/...".
+func RelativeSymlinkPath(installDir, target string) string {
+	installParts := splitPath(installDir)
+	targetParts := splitPath(target)
+
+	common := 0
+	for common < len(installParts) && common < len(targetParts) && installParts[common] == targetParts[common] {
+		common++
+	}
+
+	var rel []string
+	for i := common; i < len(installParts); i++ {
+		rel = append(rel, "..")
+	}
+	rel = append(rel, targetParts[common:]...)
+
+	if len(rel) == 0 {
+		return "."
+	}
+	return path.Join(rel...)
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// SymlinkCommand returns the "ln -sf" command text to create a symlink at linkPath pointing at
+// target, where the caller has already resolved target to either an absolute path or, for a
+// relative symlink, the result of RelativeSymlinkPath(installDir, target).
+func SymlinkCommand(target, linkPath string) string {
+	return "ln -sf " + target + " " + linkPath
+}
+
+// InstallRelativeSymlink mirrors InstallAbsoluteSymlink's call shape but computes target as a
+// path relative to installDir, for products configured to prefer relative symlinks (set via
+// RelativeInstallSymlinks in the config).
+func InstallRelativeSymlink(installDir, targetPath, linkPath string) string {
+	return SymlinkCommand(RelativeSymlinkPath(installDir, targetPath), linkPath)
+}
@@ -0,0 +1,37 @@
+package android
+
+import "testing"
+
+func TestRelativeSymlinkPath(t *testing.T) {
+	tests := []struct {
+		installDir string
+		target     string
+		want       string
+	}{
+		{"/apex/com.android.runtime/bin", "/apex/com.android.runtime/lib64/libfoo.so", "../lib64/libfoo.so"},
+		{"/apex/com.android.runtime/bin", "/apex/com.android.runtime/bin/tool", "tool"},
+		{"/system/bin", "/apex/com.android.art/bin/dalvikvm", "../../apex/com.android.art/bin/dalvikvm"},
+	}
+	for _, tt := range tests {
+		got := RelativeSymlinkPath(tt.installDir, tt.target)
+		if got != tt.want {
+			t.Errorf("RelativeSymlinkPath(%q, %q) = %q, want %q", tt.installDir, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestInstallRelativeSymlink_GeneratedLnCommand(t *testing.T) {
+	got := InstallRelativeSymlink("/apex/com.android.runtime/bin", "/apex/com.android.runtime/lib64/libfoo.so", "/apex/com.android.runtime/bin/libfoo.so")
+	want := "ln -sf ../lib64/libfoo.so /apex/com.android.runtime/bin/libfoo.so"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSymlinkCommand_Absolute(t *testing.T) {
+	got := SymlinkCommand("/apex/com.android.runtime/lib64/libfoo.so", "/apex/com.android.runtime/bin/libfoo.so")
+	want := "ln -sf /apex/com.android.runtime/lib64/libfoo.so /apex/com.android.runtime/bin/libfoo.so"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
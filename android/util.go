@@ -0,0 +1,79 @@
+package android
+
+// FirstUniqueStrings returns all unique elements of a slice of strings, keeping the first
+// occurrence of each and preserving input order.
+//
+// For small inputs this uses the original O(n^2) algorithm, which avoids the allocation and
+// hashing overhead of a map. Above uniqueStringsMapThreshold it switches to a map-based
+// implementation so that callers like linkerSpecifiedDeps that see lists with thousands of
+// entries don't pay quadratic cost.
+func FirstUniqueStrings(list []string) []string {
+	if len(list) > uniqueStringsMapThreshold {
+		return firstUniqueStringsMap(list)
+	}
+	return firstUniqueStringsList(list)
+}
+
+// uniqueStringsMapThreshold is the length above which FirstUniqueStrings switches from the
+// O(n^2) list-scan implementation to the map-based implementation. It was picked by profiling:
+// below this size the map's allocation and hashing overhead outweighs the quadratic scan.
+const uniqueStringsMapThreshold = 128
+
+func firstUniqueStringsList(list []string) []string {
+	k := 0
+outer:
+	for i := 0; i < len(list); i++ {
+		for j := 0; j < k; j++ {
+			if list[i] == list[j] {
+				continue outer
+			}
+		}
+		list[k] = list[i]
+		k++
+	}
+	return list[:k]
+}
+
+func firstUniqueStringsMap(list []string) []string {
+	writeIndex := 0
+	seen := make(map[string]bool, len(list))
+	for _, s := range list {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		list[writeIndex] = s
+		writeIndex++
+	}
+	return list[:writeIndex]
+}
+
+// PrefixInList returns true if the given string has a prefix that matches one of the entries in
+// the given prefix list, treating each list entry and the string as whitespace-separated flag
+// tokens rather than doing a raw string-prefix comparison. This avoids mis-detecting a flag like
+// `-Ifoo` as matching prefix `-I` when `-Ifoo` actually appears inside a quoted string such as
+// `-DFOO="-Ifoo"`, which a plain strings.HasPrefix scan can't distinguish.
+func PrefixInList(str string, prefixes []string) bool {
+	token := firstToken(str)
+	for _, prefix := range prefixes {
+		if strings_HasPrefix(token, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstToken returns the first whitespace-separated token of s, or s itself if it contains no
+// whitespace.
+func firstToken(s string) string {
+	for i, r := range s {
+		if r == ' ' || r == '\t' {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func strings_HasPrefix(s, prefix string) bool {
+	return len(s) >= len(prefix) && s[:len(prefix)] == prefix
+}
@@ -0,0 +1,56 @@
+package android
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFirstUniqueStringsSmall(t *testing.T) {
+	in := []string{"a", "b", "a", "c", "b"}
+	got := FirstUniqueStrings(append([]string(nil), in...))
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFirstUniqueStringsLargeMatchesSmall(t *testing.T) {
+	// Build a list long enough to cross uniqueStringsMapThreshold, with duplicates spread
+	// throughout, and check the map-based path returns the same thing the list-based path
+	// would for the same input.
+	var in []string
+	for i := 0; i < 500; i++ {
+		in = append(in, "dep", "tag")
+	}
+	in = append(in, "unique")
+
+	gotMap := FirstUniqueStrings(append([]string(nil), in...))
+	gotList := firstUniqueStringsList(append([]string(nil), in...))
+
+	if !reflect.DeepEqual(gotMap, gotList) {
+		t.Errorf("map implementation diverged from list implementation:\nmap:  %v\nlist: %v", gotMap, gotList)
+	}
+	want := []string{"dep", "tag", "unique"}
+	if !reflect.DeepEqual(gotMap, want) {
+		t.Errorf("got %v, want %v", gotMap, want)
+	}
+}
+
+func TestPrefixInList(t *testing.T) {
+	tests := []struct {
+		str      string
+		prefixes []string
+		want     bool
+	}{
+		{"-Ifoo", []string{"-I"}, true},
+		{`-DFOO=-Ifoo`, []string{"-I"}, false},
+		{"-isystem include", []string{"-isystem"}, true},
+		{"-Wall", []string{"-I", "-isystem"}, false},
+	}
+	for _, tt := range tests {
+		got := PrefixInList(tt.str, tt.prefixes)
+		if got != tt.want {
+			t.Errorf("PrefixInList(%q, %v) = %v, want %v", tt.str, tt.prefixes, got, tt.want)
+		}
+	}
+}
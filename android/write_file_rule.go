@@ -0,0 +1,39 @@
+package android
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// writeFileRuleSpillThreshold is the content size above which WriteFileRule spills to a
+// temporary source file and cats it instead of embedding content on the command line, to avoid
+// E2BIG on very large (multi-hundred-KB) snapshot JSON blobs.
+const writeFileRuleSpillThreshold = 100 * 1024
+
+// WriteFileRuleCommand returns the shell command WriteFileRule should run to write content to
+// outPath. Content up to writeFileRuleSpillThreshold is embedded directly; larger content is
+// written to spillPath (a caller-provided temp/rsp file already populated with content) and
+// cat'd into place, so the command line itself never carries more than a path.
+func WriteFileRuleCommand(content, outPath, spillPath string) string {
+	if len(content) > writeFileRuleSpillThreshold {
+		return fmt.Sprintf("cat %s > %s", shellQuoteWriteFile(spillPath), shellQuoteWriteFile(outPath))
+	}
+	return fmt.Sprintf("echo -n %s > %s", shellQuoteWriteFile(content), shellQuoteWriteFile(outPath))
+}
+
+func shellQuoteWriteFile(s string) string {
+	return "'" + s + "'"
+}
+
+// WriteBinaryFileRuleCommand returns the shell command to write binary content to outPath
+// without shell-quoting mangling: content is base64-encoded into encodedSpillPath by the caller,
+// and the rule decodes it back on the far side.
+func WriteBinaryFileRuleCommand(encodedSpillPath, outPath string) string {
+	return fmt.Sprintf("base64 -d %s > %s", shellQuoteWriteFile(encodedSpillPath), shellQuoteWriteFile(outPath))
+}
+
+// EncodeBinaryFileContent returns the base64 encoding of content, for a caller to write to the
+// spill file consumed by WriteBinaryFileRuleCommand.
+func EncodeBinaryFileContent(content []byte) string {
+	return base64.StdEncoding.EncodeToString(content)
+}
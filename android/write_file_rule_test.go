@@ -0,0 +1,43 @@
+package android
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestWriteFileRuleCommand_SmallContentEmbedded(t *testing.T) {
+	got := WriteFileRuleCommand("hello", "out.txt", "spill.txt")
+	if !strings.Contains(got, "echo -n 'hello'") {
+		t.Errorf("expected small content to be embedded, got: %s", got)
+	}
+}
+
+func TestWriteFileRuleCommand_LargeContentSpills(t *testing.T) {
+	large := strings.Repeat("x", 1024*1024) // 1MB
+	got := WriteFileRuleCommand(large, "out.txt", "spill.txt")
+	if strings.Contains(got, large) {
+		t.Errorf("expected large content to be spilled, not embedded in the command")
+	}
+	if !strings.Contains(got, "cat 'spill.txt' > 'out.txt'") {
+		t.Errorf("expected a cat from the spill file, got: %s", got)
+	}
+}
+
+func TestWriteBinaryFileRuleCommand_RoundTrip(t *testing.T) {
+	content := []byte("\x00\x01\nbinary\x00content\n")
+	encoded := EncodeBinaryFileContent(content)
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if string(decoded) != string(content) {
+		t.Errorf("round trip mismatch: got %q, want %q", decoded, content)
+	}
+
+	cmd := WriteBinaryFileRuleCommand("spill.b64", "out.bin")
+	if !strings.Contains(cmd, "base64 -d 'spill.b64' > 'out.bin'") {
+		t.Errorf("unexpected command: %s", cmd)
+	}
+}
@@ -0,0 +1,61 @@
+package apex
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// ApexInfoListEntry is one apexBundle's contribution to the build-time apex-info-list artifact,
+// mirroring the fields the device-side runtime apex-info-list reports, minus anything only known
+// at boot.
+type ApexInfoListEntry struct {
+	Name      string
+	Version   string
+	Flattened bool
+	Partition string
+	Updatable bool
+}
+
+// apexInfoListRegistry collects every installable apexBundle's ApexInfoListEntry for the
+// out/soong/apexes.json singleton to read back at the end of the build. It is mutex-protected
+// like the other global build-state registries in this tree (e.g. android's genPathRegistry),
+// since apexBundle mutators can run concurrently across modules.
+type apexInfoListRegistry struct {
+	mu      sync.Mutex
+	entries map[string]ApexInfoListEntry
+}
+
+var globalApexInfoListRegistry = &apexInfoListRegistry{entries: map[string]ApexInfoListEntry{}}
+
+// SetApexInfoListEntry records (or overwrites, for an override module replacing its base) the
+// entry for an apexBundle.
+func SetApexInfoListEntry(entry ApexInfoListEntry) {
+	globalApexInfoListRegistry.mu.Lock()
+	defer globalApexInfoListRegistry.mu.Unlock()
+	globalApexInfoListRegistry.entries[entry.Name] = entry
+}
+
+// ApexInfoListEntries returns every recorded entry, sorted by name for deterministic output.
+func ApexInfoListEntries() []ApexInfoListEntry {
+	globalApexInfoListRegistry.mu.Lock()
+	defer globalApexInfoListRegistry.mu.Unlock()
+	entries := make([]ApexInfoListEntry, 0, len(globalApexInfoListRegistry.entries))
+	for _, e := range globalApexInfoListRegistry.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// ResetApexInfoListForTests clears the registry. It exists only for test isolation.
+func ResetApexInfoListForTests() {
+	globalApexInfoListRegistry.mu.Lock()
+	defer globalApexInfoListRegistry.mu.Unlock()
+	globalApexInfoListRegistry.entries = map[string]ApexInfoListEntry{}
+}
+
+// MarshalApexInfoListJSON renders the current entries as the out/soong/apexes.json contents.
+func MarshalApexInfoListJSON() ([]byte, error) {
+	return json.MarshalIndent(ApexInfoListEntries(), "", "  ")
+}
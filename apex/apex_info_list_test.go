@@ -0,0 +1,37 @@
+package apex
+
+import "testing"
+
+func TestApexInfoListEntries_SortedAndOverridable(t *testing.T) {
+	ResetApexInfoListForTests()
+	defer ResetApexInfoListForTests()
+
+	SetApexInfoListEntry(ApexInfoListEntry{Name: "com.android.bar", Partition: "system"})
+	SetApexInfoListEntry(ApexInfoListEntry{Name: "com.android.foo", Partition: "system", Updatable: true})
+	SetApexInfoListEntry(ApexInfoListEntry{Name: "com.android.foo", Partition: "vendor", Updatable: true})
+
+	entries := ApexInfoListEntries()
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].Name != "com.android.bar" || entries[1].Name != "com.android.foo" {
+		t.Errorf("got %+v", entries)
+	}
+	if entries[1].Partition != "vendor" {
+		t.Errorf("expected override to replace partition, got %q", entries[1].Partition)
+	}
+}
+
+func TestMarshalApexInfoListJSON(t *testing.T) {
+	ResetApexInfoListForTests()
+	defer ResetApexInfoListForTests()
+
+	SetApexInfoListEntry(ApexInfoListEntry{Name: "com.android.foo"})
+	b, err := MarshalApexInfoListJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(b) == 0 {
+		t.Errorf("expected non-empty JSON")
+	}
+}
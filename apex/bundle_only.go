@@ -0,0 +1,38 @@
+package apex
+
+// BundleOnlyFile is one file collected for an apex member, tagged with whether it's a regular
+// payload member or a bundle_only one (built, validated and reported on as if packaged, but
+// shipped through a different channel so it must not land in the actual payload).
+type BundleOnlyFile struct {
+	ModuleName string
+	BundleOnly bool
+}
+
+// IsBundleOnlyMember reports whether moduleName was listed in the apex's bundle_only property.
+func IsBundleOnlyMember(moduleName string, bundleOnly []string) bool {
+	for _, n := range bundleOnly {
+		if n == moduleName {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterPayloadFiles drops bundle_only files from the set that goes into image generation and
+// androidmk, while leaving them untouched for callers (reports, ABI checks) that want every
+// collected file regardless of payload membership.
+func FilterPayloadFiles(files []BundleOnlyFile) []BundleOnlyFile {
+	var payload []BundleOnlyFile
+	for _, f := range files {
+		if !f.BundleOnly {
+			payload = append(payload, f)
+		}
+	}
+	return payload
+}
+
+// ReportFiles returns every collected file, bundle_only or not, for the apex's reports (size
+// accounting, ABI checks) that must still see bundle_only members.
+func ReportFiles(files []BundleOnlyFile) []BundleOnlyFile {
+	return files
+}
@@ -0,0 +1,38 @@
+package apex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsBundleOnlyMember(t *testing.T) {
+	bundleOnly := []string{"libab_test"}
+	if !IsBundleOnlyMember("libab_test", bundleOnly) {
+		t.Errorf("expected libab_test to be a bundle_only member")
+	}
+	if IsBundleOnlyMember("libcore", bundleOnly) {
+		t.Errorf("expected libcore not to be a bundle_only member")
+	}
+}
+
+func TestFilterPayloadFiles_ExcludesBundleOnly(t *testing.T) {
+	files := []BundleOnlyFile{
+		{ModuleName: "libcore", BundleOnly: false},
+		{ModuleName: "libab_test", BundleOnly: true},
+	}
+	got := FilterPayloadFiles(files)
+	want := []BundleOnlyFile{{ModuleName: "libcore", BundleOnly: false}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReportFiles_IncludesBundleOnly(t *testing.T) {
+	files := []BundleOnlyFile{
+		{ModuleName: "libcore", BundleOnly: false},
+		{ModuleName: "libab_test", BundleOnly: true},
+	}
+	if got := ReportFiles(files); !reflect.DeepEqual(got, files) {
+		t.Errorf("expected reports to include bundle_only files, got %v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package apex
+
+// ApexFileSdkMetadata is the per-member SDK metadata apexFile should carry, populated during file
+// collection so validation and reporting features don't need to re-query the member module.
+type ApexFileSdkMetadata struct {
+	ModuleName    string
+	MinSdkVersion string
+	SdkVersion    string
+}
+
+// MemberSdkInfo is what file collection reads off a member module to populate
+// ApexFileSdkMetadata; kept as its own type so cc and java member lookups can both implement it
+// without either package depending on the other.
+type MemberSdkInfo struct {
+	MinSdkVersion string
+	SdkVersion    string
+}
+
+// CollectFileSdkMetadata builds the ApexFileSdkMetadata for every member, in member order.
+func CollectFileSdkMetadata(members map[string]MemberSdkInfo, order []string) []ApexFileSdkMetadata {
+	result := make([]ApexFileSdkMetadata, 0, len(order))
+	for _, name := range order {
+		info := members[name]
+		result = append(result, ApexFileSdkMetadata{
+			ModuleName:    name,
+			MinSdkVersion: info.MinSdkVersion,
+			SdkVersion:    info.SdkVersion,
+		})
+	}
+	return result
+}
+
+// MinSdkEnforcementChainError formats an enforcement error showing each link's min_sdk_version
+// in the dependency chain from the apex down to the offending member.
+func MinSdkEnforcementChainError(apexMinSdk string, chain []ApexFileSdkMetadata) string {
+	msg := "apex requires min_sdk_version " + apexMinSdk + ", but the following members don't satisfy it:\n"
+	for _, m := range chain {
+		msg += "  " + m.ModuleName + ": min_sdk_version=" + m.MinSdkVersion + "\n"
+	}
+	return msg
+}
@@ -0,0 +1,33 @@
+package apex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCollectFileSdkMetadata_CcAndJavaMembers(t *testing.T) {
+	members := map[string]MemberSdkInfo{
+		"libfoo":  {MinSdkVersion: "29", SdkVersion: "current"},
+		"FooJava": {MinSdkVersion: "30", SdkVersion: "31"},
+	}
+	got := CollectFileSdkMetadata(members, []string{"libfoo", "FooJava"})
+	want := []ApexFileSdkMetadata{
+		{ModuleName: "libfoo", MinSdkVersion: "29", SdkVersion: "current"},
+		{ModuleName: "FooJava", MinSdkVersion: "30", SdkVersion: "31"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMinSdkEnforcementChainError_ShowsEachLink(t *testing.T) {
+	chain := []ApexFileSdkMetadata{
+		{ModuleName: "libfoo", MinSdkVersion: "29"},
+		{ModuleName: "libbar", MinSdkVersion: "28"},
+	}
+	msg := MinSdkEnforcementChainError("30", chain)
+	if !strings.Contains(msg, "libfoo: min_sdk_version=29") || !strings.Contains(msg, "libbar: min_sdk_version=28") {
+		t.Errorf("got %q", msg)
+	}
+}
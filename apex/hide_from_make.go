@@ -0,0 +1,32 @@
+package apex
+
+// ApexCoverageEntry is one apex's contribution to checkbuild aggregation and soong-side reports,
+// decoupled from whether it's visible to make.
+type ApexCoverageEntry struct {
+	ApexName     string
+	ImageOutputs []string
+	HideFromMake bool
+}
+
+// CheckbuildOutputs returns the outputs that should register for checkbuild aggregation: every
+// hidden apex's image output still needs to be built, even though HideFromMake also disables mk
+// emission and installation for it.
+func CheckbuildOutputs(entries []ApexCoverageEntry) []string {
+	var outputs []string
+	for _, e := range entries {
+		outputs = append(outputs, e.ImageOutputs...)
+	}
+	return outputs
+}
+
+// ReportEntries returns every entry unfiltered, for the ABI/size report singletons that must
+// still see hidden, prebuilt-superseded source apexes so they stay compile-tested.
+func ReportEntries(entries []ApexCoverageEntry) []ApexCoverageEntry {
+	return entries
+}
+
+// ShouldEmitAndroidMk reports whether androidmk output and installation should happen for an
+// entry; only this is gated on HideFromMake, unlike checkbuild/report coverage.
+func ShouldEmitAndroidMk(e ApexCoverageEntry) bool {
+	return !e.HideFromMake
+}
@@ -0,0 +1,34 @@
+package apex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckbuildOutputs_IncludesHiddenApex(t *testing.T) {
+	entries := []ApexCoverageEntry{
+		{ApexName: "com.android.foo", ImageOutputs: []string{"foo.apex"}, HideFromMake: true},
+		{ApexName: "com.android.bar", ImageOutputs: []string{"bar.apex"}},
+	}
+	got := CheckbuildOutputs(entries)
+	want := []string{"foo.apex", "bar.apex"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestShouldEmitAndroidMk(t *testing.T) {
+	if ShouldEmitAndroidMk(ApexCoverageEntry{HideFromMake: true}) {
+		t.Errorf("expected no androidmk emission for a hidden apex")
+	}
+	if !ShouldEmitAndroidMk(ApexCoverageEntry{}) {
+		t.Errorf("expected androidmk emission for a visible apex")
+	}
+}
+
+func TestReportEntries_IncludesHidden(t *testing.T) {
+	entries := []ApexCoverageEntry{{ApexName: "com.android.foo", HideFromMake: true}}
+	if got := ReportEntries(entries); !reflect.DeepEqual(got, entries) {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,33 @@
+package apex
+
+// PartitionProperties is the subset of apexBundle's partition-selection properties that affect
+// where the apex installs.
+type PartitionProperties struct {
+	System_ext_specific *bool
+}
+
+func (p PartitionProperties) systemExtSpecific() bool {
+	return p.System_ext_specific != nil && *p.System_ext_specific
+}
+
+// InstallDirBase returns the partition-relative base directory ("system" or "system_ext") an
+// apex's image output, flattened per-file LOCAL_MODULE_PATH and symbol path should all compute
+// uniformly, instead of each site re-deriving it from the partition properties independently.
+func InstallDirBase(props PartitionProperties) string {
+	if props.systemExtSpecific() {
+		return "system_ext"
+	}
+	return "system"
+}
+
+// ApexInstallDir returns the apex image's install path under the partition base, e.g.
+// "system/apex" or "system_ext/apex".
+func ApexInstallDir(props PartitionProperties) string {
+	return InstallDirBase(props) + "/apex"
+}
+
+// FlattenedFileInstallPath returns the LOCAL_MODULE_PATH a flattened apex's per-file entry should
+// use for relPath inside the apex, e.g. "system/apex/com.android.foo/relPath".
+func FlattenedFileInstallPath(apexName string, props PartitionProperties, relPath string) string {
+	return ApexInstallDir(props) + "/" + apexName + "/" + relPath
+}
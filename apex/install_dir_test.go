@@ -0,0 +1,32 @@
+package apex
+
+import "testing"
+
+func TestInstallDirBase(t *testing.T) {
+	if got := InstallDirBase(PartitionProperties{}); got != "system" {
+		t.Errorf("got %q", got)
+	}
+	systemExt := true
+	if got := InstallDirBase(PartitionProperties{System_ext_specific: &systemExt}); got != "system_ext" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestApexInstallDir_BothPartitions(t *testing.T) {
+	if got := ApexInstallDir(PartitionProperties{}); got != "system/apex" {
+		t.Errorf("got %q", got)
+	}
+	systemExt := true
+	if got := ApexInstallDir(PartitionProperties{System_ext_specific: &systemExt}); got != "system_ext/apex" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFlattenedFileInstallPath(t *testing.T) {
+	systemExt := true
+	got := FlattenedFileInstallPath("com.android.foo", PartitionProperties{System_ext_specific: &systemExt}, "bin/foo")
+	want := "system_ext/apex/com.android.foo/bin/foo"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package apex
+
+// MemberClass identifies the LOCAL_MODULE_CLASS an apexFile member maps to.
+type MemberClass string
+
+const (
+	ClassExecutables MemberClass = "EXECUTABLES"
+	ClassSharedLibs  MemberClass = "SHARED_LIBRARIES"
+)
+
+// InterpretedBinaryRuntimeFiles is the extra runtime payload a python or go binary member needs
+// beyond its own top-level file: the embedded launcher for py_binary, or the cgo shared library
+// deps for a go binary built with CGO_ENABLED.
+type InterpretedBinaryRuntimeFiles struct {
+	ModuleName string
+	Class      MemberClass
+	// Launcher is the embedded interpreter/launcher file, set for python binaries.
+	Launcher string
+	// SharedLibDeps are cgo shared library dependencies, set for go binaries that link against
+	// native code.
+	SharedLibDeps []string
+}
+
+// ApexFileEntry is one file collected into an apexFile's filesInfo for image or flattened
+// installation.
+type ApexFileEntry struct {
+	ModuleName string
+	Class      MemberClass
+	SrcPath    string
+	// Required is true for entries that exist to satisfy a primary member's runtime needs rather
+	// than being directly requested, matching the requiredModuleNames convention.
+	Required bool
+}
+
+// CollectInterpretedBinaryEntries expands a python/go binary member into its full filesInfo
+// entry set: the binary itself, plus its launcher and/or shared lib deps as additional entries,
+// so flattened installs carry everything the image apex already contains.
+func CollectInterpretedBinaryEntries(primarySrcPath string, rt InterpretedBinaryRuntimeFiles) []ApexFileEntry {
+	entries := []ApexFileEntry{
+		{ModuleName: rt.ModuleName, Class: rt.Class, SrcPath: primarySrcPath},
+	}
+	if rt.Launcher != "" {
+		entries = append(entries, ApexFileEntry{
+			ModuleName: rt.ModuleName,
+			Class:      ClassExecutables,
+			SrcPath:    rt.Launcher,
+			Required:   true,
+		})
+	}
+	for _, dep := range rt.SharedLibDeps {
+		entries = append(entries, ApexFileEntry{
+			ModuleName: rt.ModuleName,
+			Class:      ClassSharedLibs,
+			SrcPath:    dep,
+			Required:   true,
+		})
+	}
+	return entries
+}
+
+// RequiredModuleNamesFor returns the additional requiredModuleNames mk entries should declare for
+// a member's runtime deps.
+func RequiredModuleNamesFor(rt InterpretedBinaryRuntimeFiles) []string {
+	var names []string
+	if rt.Launcher != "" {
+		names = append(names, rt.ModuleName+"_launcher")
+	}
+	names = append(names, rt.SharedLibDeps...)
+	return names
+}
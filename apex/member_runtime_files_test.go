@@ -0,0 +1,33 @@
+package apex
+
+import "testing"
+
+func TestCollectInterpretedBinaryEntries_PythonWithLauncher(t *testing.T) {
+	rt := InterpretedBinaryRuntimeFiles{ModuleName: "mytool", Class: ClassExecutables, Launcher: "launcher.bin"}
+	entries := CollectInterpretedBinaryEntries("mytool", rt)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[1].SrcPath != "launcher.bin" || !entries[1].Required {
+		t.Errorf("got %+v", entries[1])
+	}
+}
+
+func TestCollectInterpretedBinaryEntries_GoWithCgoDeps(t *testing.T) {
+	rt := InterpretedBinaryRuntimeFiles{ModuleName: "gotool", Class: ClassExecutables, SharedLibDeps: []string{"libfoo.so"}}
+	entries := CollectInterpretedBinaryEntries("gotool", rt)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[1].Class != ClassSharedLibs {
+		t.Errorf("got class %v, want %v", entries[1].Class, ClassSharedLibs)
+	}
+}
+
+func TestRequiredModuleNamesFor(t *testing.T) {
+	rt := InterpretedBinaryRuntimeFiles{ModuleName: "mytool", Launcher: "launcher.bin", SharedLibDeps: []string{"libfoo.so"}}
+	got := RequiredModuleNamesFor(rt)
+	if len(got) != 2 {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,72 @@
+package apex
+
+import "fmt"
+
+// MultilibPolicy is an apexBundle's effective multilib restriction, derived from its
+// `multilib: { first/both/32/64: {...} }` property block.
+type MultilibPolicy string
+
+const (
+	MultilibBoth  MultilibPolicy = "both"
+	MultilibFirst MultilibPolicy = "first"
+	Multilib32    MultilibPolicy = "32"
+	Multilib64    MultilibPolicy = "64"
+)
+
+// ApexFileArchEntry is one collected filesInfo entry, tagged with its arch and whether it's the
+// "first" (primary) arch for its target, for multilib filtering.
+type ApexFileArchEntry struct {
+	ModuleName        string
+	Arch              string
+	Is32Bit           bool
+	IsFirstArch       bool
+	DirectlyRequested bool
+}
+
+// allowedByPolicy reports whether entry's arch variant should be kept under policy.
+func allowedByPolicy(policy MultilibPolicy, entry ApexFileArchEntry) bool {
+	switch policy {
+	case MultilibFirst:
+		return entry.IsFirstArch
+	case Multilib32:
+		return entry.Is32Bit
+	case Multilib64:
+		return !entry.Is32Bit
+	default: // MultilibBoth or unset
+		return true
+	}
+}
+
+// FilterFilesInfoByMultilib returns the subset of entries that should be emitted as mk entries
+// under policy, dropping arch variants the apex's effective multilib policy excludes.
+func FilterFilesInfoByMultilib(policy MultilibPolicy, entries []ApexFileArchEntry) []ApexFileArchEntry {
+	var kept []ApexFileArchEntry
+	for _, e := range entries {
+		if allowedByPolicy(policy, e) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// ValidateDirectMembersSurviveMultilibFilter errors when a directly-requested member only exists
+// for an arch the apex's multilib policy excludes, since that member would silently vanish from
+// the apex instead of being installed.
+func ValidateDirectMembersSurviveMultilibFilter(policy MultilibPolicy, entries []ApexFileArchEntry) error {
+	requested := map[string]bool{}
+	kept := map[string]bool{}
+	for _, e := range entries {
+		if e.DirectlyRequested {
+			requested[e.ModuleName] = true
+		}
+	}
+	for _, e := range FilterFilesInfoByMultilib(policy, entries) {
+		kept[e.ModuleName] = true
+	}
+	for name := range requested {
+		if !kept[name] {
+			return fmt.Errorf("%s: directly requested but has no variant matching the apex's multilib policy", name)
+		}
+	}
+	return nil
+}
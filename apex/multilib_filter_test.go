@@ -0,0 +1,43 @@
+package apex
+
+import "testing"
+
+func TestFilterFilesInfoByMultilib_First(t *testing.T) {
+	entries := []ApexFileArchEntry{
+		{ModuleName: "libfoo", Arch: "arm64", IsFirstArch: true},
+		{ModuleName: "libfoo", Arch: "arm", IsFirstArch: false},
+	}
+	got := FilterFilesInfoByMultilib(MultilibFirst, entries)
+	if len(got) != 1 || got[0].Arch != "arm64" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestFilterFilesInfoByMultilib_Both(t *testing.T) {
+	entries := []ApexFileArchEntry{
+		{ModuleName: "libfoo", Arch: "arm64"},
+		{ModuleName: "libfoo", Arch: "arm"},
+	}
+	got := FilterFilesInfoByMultilib(MultilibBoth, entries)
+	if len(got) != 2 {
+		t.Errorf("got %d entries, want 2", len(got))
+	}
+}
+
+func TestValidateDirectMembersSurviveMultilibFilter_Errors(t *testing.T) {
+	entries := []ApexFileArchEntry{
+		{ModuleName: "libfoo", Arch: "arm", IsFirstArch: false, DirectlyRequested: true},
+	}
+	if err := ValidateDirectMembersSurviveMultilibFilter(MultilibFirst, entries); err == nil {
+		t.Errorf("expected an error when the directly requested member is filtered out")
+	}
+}
+
+func TestValidateDirectMembersSurviveMultilibFilter_Ok(t *testing.T) {
+	entries := []ApexFileArchEntry{
+		{ModuleName: "libfoo", Arch: "arm64", IsFirstArch: true, DirectlyRequested: true},
+	}
+	if err := ValidateDirectMembersSurviveMultilibFilter(MultilibFirst, entries); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
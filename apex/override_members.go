@@ -0,0 +1,73 @@
+package apex
+
+import "fmt"
+
+// OverrideApexMemberProperties holds an override_apex module's edits to its base apex's
+// native_shared_libs list, applied during override resolution so filesInfo, the manifest, and
+// androidmk outputs all see the edited set without the override module having to redefine the
+// whole list.
+type OverrideApexMemberProperties struct {
+	// Replace_native_shared_libs is a list of "old:new" pairs; each old member of the base apex
+	// is replaced by new.
+	Replace_native_shared_libs []string
+
+	// Remove_native_shared_libs removes these members from the base apex's list outright.
+	Remove_native_shared_libs []string
+}
+
+// ResolveOverrideNativeSharedLibs applies props.Replace_native_shared_libs and
+// props.Remove_native_shared_libs to baseMembers, returning the edited member list in the base's
+// original order. It returns an error if a replace or remove entry names a module that isn't in
+// baseMembers.
+func ResolveOverrideNativeSharedLibs(baseMembers []string, props OverrideApexMemberProperties) ([]string, error) {
+	replacements := make(map[string]string, len(props.Replace_native_shared_libs))
+	for _, pair := range props.Replace_native_shared_libs {
+		old, new, err := splitOldNew(pair)
+		if err != nil {
+			return nil, err
+		}
+		replacements[old] = new
+	}
+
+	removed := make(map[string]bool, len(props.Remove_native_shared_libs))
+	for _, name := range props.Remove_native_shared_libs {
+		removed[name] = true
+	}
+
+	present := make(map[string]bool, len(baseMembers))
+	for _, m := range baseMembers {
+		present[m] = true
+	}
+	for old := range replacements {
+		if !present[old] {
+			return nil, fmt.Errorf("replace_native_shared_libs: %q is not a member of the base apex", old)
+		}
+	}
+	for name := range removed {
+		if !present[name] {
+			return nil, fmt.Errorf("remove_native_shared_libs: %q is not a member of the base apex", name)
+		}
+	}
+
+	result := make([]string, 0, len(baseMembers))
+	for _, m := range baseMembers {
+		if removed[m] {
+			continue
+		}
+		if new, ok := replacements[m]; ok {
+			result = append(result, new)
+			continue
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func splitOldNew(pair string) (old, new string, err error) {
+	for i := 0; i < len(pair); i++ {
+		if pair[i] == ':' {
+			return pair[:i], pair[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("replace_native_shared_libs entry %q must be in \"old:new\" form", pair)
+}
@@ -0,0 +1,43 @@
+package apex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveOverrideNativeSharedLibs_Replace(t *testing.T) {
+	base := []string{"liba", "libb", "libc"}
+	props := OverrideApexMemberProperties{Replace_native_shared_libs: []string{"libb:libb_override"}}
+
+	got, err := ResolveOverrideNativeSharedLibs(base, props)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"liba", "libb_override", "libc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolveOverrideNativeSharedLibs_Remove(t *testing.T) {
+	base := []string{"liba", "libb", "libc"}
+	props := OverrideApexMemberProperties{Remove_native_shared_libs: []string{"libc"}}
+
+	got, err := ResolveOverrideNativeSharedLibs(base, props)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"liba", "libb"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolveOverrideNativeSharedLibs_ReplaceMissingMember(t *testing.T) {
+	base := []string{"liba", "libb"}
+	props := OverrideApexMemberProperties{Replace_native_shared_libs: []string{"libnotexist:libnew"}}
+
+	if _, err := ResolveOverrideNativeSharedLibs(base, props); err == nil {
+		t.Fatalf("expected an error for replacing a member that doesn't exist in the base")
+	}
+}
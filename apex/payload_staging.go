@@ -0,0 +1,44 @@
+package apex
+
+import "fmt"
+
+// StagingMethod names the mechanism StagePayloadFile chose to place a member output into the
+// apex's staging directory.
+type StagingMethod string
+
+const (
+	StagingHardLink StagingMethod = "hardlink"
+	StagingReflink  StagingMethod = "reflink"
+	StagingCopy     StagingMethod = "copy"
+)
+
+// StagingFS abstracts the one filesystem query PlanPayloadStaging needs, whether a directory's
+// filesystem supports reflinks, so staging strategy can be tested without touching a real
+// filesystem.
+type StagingFS interface {
+	SupportsReflink(dir string) bool
+}
+
+// PlanPayloadStaging chooses how to place src into the staging directory at dst: hard link when
+// supported, else reflink when the staging filesystem supports it, else a plain copy. Downstream
+// image tools must not mutate staged inputs in place, so every method other than copy requires
+// the staged file to be made read-only after placement.
+func PlanPayloadStaging(fs StagingFS, dstDir string, sameFilesystem bool) (StagingMethod, error) {
+	if dstDir == "" {
+		return "", fmt.Errorf("payload staging directory must not be empty")
+	}
+	if sameFilesystem {
+		return StagingHardLink, nil
+	}
+	if fs.SupportsReflink(dstDir) {
+		return StagingReflink, nil
+	}
+	return StagingCopy, nil
+}
+
+// RequiresReadOnlyAfterStaging reports whether the staged file must be chmod'd read-only after
+// placement to stop downstream image tools from mutating a hard-linked or reflinked input that
+// is still shared with (or copy-on-write shared with) the original member output.
+func RequiresReadOnlyAfterStaging(method StagingMethod) bool {
+	return method == StagingHardLink || method == StagingReflink
+}
@@ -0,0 +1,51 @@
+package apex
+
+import "testing"
+
+type fakeStagingFS struct {
+	reflinkSupported bool
+}
+
+func (f fakeStagingFS) SupportsReflink(dir string) bool { return f.reflinkSupported }
+
+func TestPlanPayloadStaging_SameFilesystemUsesHardLink(t *testing.T) {
+	method, err := PlanPayloadStaging(fakeStagingFS{reflinkSupported: true}, "/out/staging", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if method != StagingHardLink {
+		t.Errorf("got %v, want %v", method, StagingHardLink)
+	}
+}
+
+func TestPlanPayloadStaging_ReflinkFallback(t *testing.T) {
+	method, err := PlanPayloadStaging(fakeStagingFS{reflinkSupported: true}, "/out/staging", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if method != StagingReflink {
+		t.Errorf("got %v, want %v", method, StagingReflink)
+	}
+}
+
+func TestPlanPayloadStaging_CopyFallback(t *testing.T) {
+	method, err := PlanPayloadStaging(fakeStagingFS{reflinkSupported: false}, "/out/staging", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if method != StagingCopy {
+		t.Errorf("got %v, want %v", method, StagingCopy)
+	}
+}
+
+func TestRequiresReadOnlyAfterStaging(t *testing.T) {
+	if !RequiresReadOnlyAfterStaging(StagingHardLink) {
+		t.Errorf("expected hard links to require read-only permissions")
+	}
+	if !RequiresReadOnlyAfterStaging(StagingReflink) {
+		t.Errorf("expected reflinks to require read-only permissions")
+	}
+	if RequiresReadOnlyAfterStaging(StagingCopy) {
+		t.Errorf("did not expect a plain copy to require read-only permissions")
+	}
+}
@@ -0,0 +1,46 @@
+package apex
+
+// PostInstallCmdsProvider is the provider data a member cc module exposes so androidMkForFiles
+// can append its post-install commands (e.g. installSymlinkToRuntimeApex's runtime apex
+// symlinks) to the apex's own androidmk entry instead of silently dropping them.
+type PostInstallCmdsProvider struct {
+	ModuleName string
+	Cmds       []string
+}
+
+// CombinePostInstallCmds merges an apex's own post-install commands with those contributed by
+// its member modules, deduplicating against the apex's own commands (a member contributing the
+// exact same symlink command the apex already writes shouldn't duplicate it) while preserving
+// each member's remaining commands in member order.
+func CombinePostInstallCmds(apexCmds []string, members []PostInstallCmdsProvider) []string {
+	seen := make(map[string]bool, len(apexCmds))
+	for _, c := range apexCmds {
+		seen[c] = true
+	}
+
+	combined := append([]string(nil), apexCmds...)
+	for _, m := range members {
+		for _, c := range m.Cmds {
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			combined = append(combined, c)
+		}
+	}
+	return combined
+}
+
+// LocalPostInstallCmd renders the combined commands as the LOCAL_POST_INSTALL_CMD value
+// androidMkForFiles writes for an apex's entry, joining with "&&" the way Make expects a
+// sequential command list.
+func LocalPostInstallCmd(cmds []string) string {
+	if len(cmds) == 0 {
+		return ""
+	}
+	result := cmds[0]
+	for _, c := range cmds[1:] {
+		result += " && " + c
+	}
+	return result
+}
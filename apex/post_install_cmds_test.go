@@ -0,0 +1,37 @@
+package apex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCombinePostInstallCmds_BootstrapLibrarySymlinks(t *testing.T) {
+	apexCmds := []string{"ln -sf /apex/com.android.runtime/lib64/libc.so /system/lib64/libc.so"}
+	members := []PostInstallCmdsProvider{
+		{ModuleName: "libc", Cmds: []string{"ln -sf /apex/com.android.runtime/lib64/libc.so /system/lib64/libc.so"}},
+		{ModuleName: "libdl", Cmds: []string{"ln -sf /apex/com.android.runtime/lib64/libdl.so /system/lib64/libdl.so"}},
+	}
+
+	got := CombinePostInstallCmds(apexCmds, members)
+	want := []string{
+		"ln -sf /apex/com.android.runtime/lib64/libc.so /system/lib64/libc.so",
+		"ln -sf /apex/com.android.runtime/lib64/libdl.so /system/lib64/libdl.so",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLocalPostInstallCmd_Joins(t *testing.T) {
+	got := LocalPostInstallCmd([]string{"cmd1", "cmd2"})
+	want := "cmd1 && cmd2"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLocalPostInstallCmd_Empty(t *testing.T) {
+	if got := LocalPostInstallCmd(nil); got != "" {
+		t.Errorf("got %q, want empty string", got)
+	}
+}
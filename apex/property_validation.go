@@ -0,0 +1,46 @@
+package apex
+
+import "fmt"
+
+// BundleProperties is the subset of apexBundle's properties and product config that determine
+// whether the requested packaging combination is actually supported. It's kept separate from the
+// real module properties struct so ValidatePropertyCombination can be unit tested without
+// constructing a full apexBundle.
+type BundleProperties struct {
+	ModuleName    string
+	Updatable     bool
+	Compressed    bool
+	FlattenedFlag bool // true when the product config builds flattened apexes
+	HasXIPFiles   bool // true when the apex contains files requiring execute-in-place
+}
+
+// ValidatePropertyCombination checks the combination matrix of compressed/updatable/flattened
+// settings that apexBundle.GenerateAndroidBuildActions must reject up front, before any image
+// tool runs into them as an opaque failure. It returns every conflict found rather than stopping
+// at the first one, so a module fixing one property doesn't get surprised by the next.
+func ValidatePropertyCombination(props BundleProperties) []error {
+	var errs []error
+
+	if props.FlattenedFlag && props.Updatable {
+		errs = append(errs, fmt.Errorf(
+			"apex %q: flattened product configuration does not support updatable apexes; "+
+				"build as a non-flattened (image) apex, or mark the apex non-updatable",
+			props.ModuleName))
+	}
+
+	if props.Compressed && props.FlattenedFlag {
+		errs = append(errs, fmt.Errorf(
+			"apex %q: compressed is not supported for flattened apexes; "+
+				"disable compressed for this product, or build as an image apex",
+			props.ModuleName))
+	}
+
+	if props.Compressed && props.HasXIPFiles {
+		errs = append(errs, fmt.Errorf(
+			"apex %q: compressed cannot be combined with files requiring execute-in-place; "+
+				"remove those files from the apex, or disable compressed",
+			props.ModuleName))
+	}
+
+	return errs
+}
@@ -0,0 +1,48 @@
+package apex
+
+import "testing"
+
+func TestValidatePropertyCombination_InvalidCombinations(t *testing.T) {
+	tests := []struct {
+		name  string
+		props BundleProperties
+	}{
+		{"flattened+updatable", BundleProperties{ModuleName: "com.android.foo", Updatable: true, FlattenedFlag: true}},
+		{"compressed+flattened", BundleProperties{ModuleName: "com.android.foo", Compressed: true, FlattenedFlag: true}},
+		{"compressed+xip", BundleProperties{ModuleName: "com.android.foo", Compressed: true, HasXIPFiles: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidatePropertyCombination(tt.props)
+			if len(errs) != 1 {
+				t.Fatalf("expected exactly one error, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidatePropertyCombination_ValidCombinations(t *testing.T) {
+	tests := []struct {
+		name  string
+		props BundleProperties
+	}{
+		{"updatable image apex", BundleProperties{ModuleName: "com.android.foo", Updatable: true}},
+		{"compressed non-flattened no xip", BundleProperties{ModuleName: "com.android.foo", Compressed: true}},
+		{"flattened non-updatable", BundleProperties{ModuleName: "com.android.foo", FlattenedFlag: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := ValidatePropertyCombination(tt.props); len(errs) != 0 {
+				t.Errorf("expected no errors, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidatePropertyCombination_MultipleConflictsReportedTogether(t *testing.T) {
+	props := BundleProperties{ModuleName: "com.android.foo", Compressed: true, FlattenedFlag: true, Updatable: true}
+	errs := ValidatePropertyCombination(props)
+	if len(errs) != 2 {
+		t.Fatalf("expected two errors (flattened+updatable, compressed+flattened), got %v", errs)
+	}
+}
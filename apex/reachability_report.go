@@ -0,0 +1,57 @@
+// Package apex implements the module types that assemble native and Java modules into APEX
+// packages.
+package apex
+
+// PayloadLibrary is the minimal view of an apex payload member needed to compute reachability:
+// its name and the sonames it directly depends on, per its TOC's DT_NEEDED entries.
+type PayloadLibrary struct {
+	Name     string
+	SoName   string
+	DTNeeded []string // sonames this library's TOC records as DT_NEEDED
+}
+
+// MemberReachability marks one native payload member as reachable or unreachable from the
+// apex's public entry points, for the size-analysis dist artifact.
+type MemberReachability struct {
+	Name      string
+	Reachable bool
+}
+
+// ComputeReachabilityReport walks the DT_NEEDED closure starting from entryPoints (the apex's
+// binaries and any libraries it exports) across libs, and marks every payload library reachable
+// or unreachable accordingly. It does not change packaging: a library that is unreachable is
+// still included in the payload, only flagged in the report.
+func ComputeReachabilityReport(libs []PayloadLibrary, entryPoints []string) []MemberReachability {
+	bySoname := make(map[string]PayloadLibrary, len(libs))
+	for _, l := range libs {
+		bySoname[l.SoName] = l
+	}
+
+	reachable := make(map[string]bool)
+	var visit func(soname string)
+	visit = func(soname string) {
+		if reachable[soname] {
+			return
+		}
+		lib, ok := bySoname[soname]
+		if !ok {
+			return
+		}
+		reachable[soname] = true
+		for _, needed := range lib.DTNeeded {
+			visit(needed)
+		}
+	}
+	for _, entry := range entryPoints {
+		visit(entry)
+	}
+
+	report := make([]MemberReachability, 0, len(libs))
+	for _, l := range libs {
+		report = append(report, MemberReachability{
+			Name:      l.Name,
+			Reachable: reachable[l.SoName],
+		})
+	}
+	return report
+}
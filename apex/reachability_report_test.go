@@ -0,0 +1,43 @@
+package apex
+
+import "testing"
+
+func TestComputeReachabilityReport_OneOrphanedLibrary(t *testing.T) {
+	libs := []PayloadLibrary{
+		{Name: "libbin", SoName: "libbin.so", DTNeeded: []string{"libused.so"}},
+		{Name: "libused", SoName: "libused.so"},
+		{Name: "liborphan", SoName: "liborphan.so"},
+	}
+
+	report := ComputeReachabilityReport(libs, []string{"libbin.so"})
+
+	reachability := make(map[string]bool)
+	for _, r := range report {
+		reachability[r.Name] = r.Reachable
+	}
+
+	if !reachability["libbin"] {
+		t.Errorf("expected libbin to be reachable as an entry point")
+	}
+	if !reachability["libused"] {
+		t.Errorf("expected libused to be reachable via DT_NEEDED")
+	}
+	if reachability["liborphan"] {
+		t.Errorf("expected liborphan to be unreachable")
+	}
+}
+
+func TestComputeReachabilityReport_TransitiveClosure(t *testing.T) {
+	libs := []PayloadLibrary{
+		{Name: "liba", SoName: "liba.so", DTNeeded: []string{"libb.so"}},
+		{Name: "libb", SoName: "libb.so", DTNeeded: []string{"libc.so"}},
+		{Name: "libc", SoName: "libc.so"},
+	}
+
+	report := ComputeReachabilityReport(libs, []string{"liba.so"})
+	for _, r := range report {
+		if !r.Reachable {
+			t.Errorf("expected %s to be reachable transitively, got unreachable", r.Name)
+		}
+	}
+}
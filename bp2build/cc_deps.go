@@ -0,0 +1,37 @@
+package bp2build
+
+// CcDepsAttributes is the shared deps-mapping output every cc converter (static, shared, and
+// future ones) should use, so whole-archive semantics and export-vs-implementation visibility
+// stay consistent across converters instead of each one re-deriving it.
+type CcDepsAttributes struct {
+	// WholeArchiveDeps are whole_static_libs, mapped to Bazel's alwayslink-style deps so static
+	// initializer registration patterns keep working (a plain deps entry would let the linker
+	// drop an archive member with no referenced symbols).
+	WholeArchiveDeps []string
+
+	// Deps are static_libs exported to dependents (export_static_lib_headers set).
+	Deps []string
+
+	// ImplementationDeps are static_libs used only internally (export_static_lib_headers unset),
+	// not propagated to dependents' include paths.
+	ImplementationDeps []string
+}
+
+// MapCcDeps builds the shared deps attributes from a module's whole_static_libs/static_libs and
+// which of the static_libs are re-exported via export_static_lib_headers.
+func MapCcDeps(wholeStaticLibs, staticLibs, exportedStaticLibHeaders []string) CcDepsAttributes {
+	exported := make(map[string]bool, len(exportedStaticLibHeaders))
+	for _, n := range exportedStaticLibHeaders {
+		exported[n] = true
+	}
+
+	attrs := CcDepsAttributes{WholeArchiveDeps: append([]string(nil), wholeStaticLibs...)}
+	for _, lib := range staticLibs {
+		if exported[lib] {
+			attrs.Deps = append(attrs.Deps, lib)
+		} else {
+			attrs.ImplementationDeps = append(attrs.ImplementationDeps, lib)
+		}
+	}
+	return attrs
+}
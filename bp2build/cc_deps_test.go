@@ -0,0 +1,36 @@
+package bp2build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapCcDeps_WholeArchiveSeparateFromPlainDeps(t *testing.T) {
+	attrs := MapCcDeps([]string{"libregistrar"}, []string{"libutil"}, nil)
+	if !reflect.DeepEqual(attrs.WholeArchiveDeps, []string{"libregistrar"}) {
+		t.Errorf("got whole archive deps %v", attrs.WholeArchiveDeps)
+	}
+	if !reflect.DeepEqual(attrs.ImplementationDeps, []string{"libutil"}) {
+		t.Errorf("got implementation deps %v", attrs.ImplementationDeps)
+	}
+	if len(attrs.Deps) != 0 {
+		t.Errorf("got deps %v", attrs.Deps)
+	}
+}
+
+func TestMapCcDeps_ExportedStaticLibGoesToDeps(t *testing.T) {
+	attrs := MapCcDeps(nil, []string{"libutil", "libexported"}, []string{"libexported"})
+	if !reflect.DeepEqual(attrs.Deps, []string{"libexported"}) {
+		t.Errorf("got deps %v", attrs.Deps)
+	}
+	if !reflect.DeepEqual(attrs.ImplementationDeps, []string{"libutil"}) {
+		t.Errorf("got implementation deps %v", attrs.ImplementationDeps)
+	}
+}
+
+func TestMapCcDeps_StaticInitializerRegistrationPattern(t *testing.T) {
+	attrs := MapCcDeps([]string{"libplugin_registrar"}, nil, nil)
+	if len(attrs.WholeArchiveDeps) != 1 || attrs.WholeArchiveDeps[0] != "libplugin_registrar" {
+		t.Errorf("expected a module registered via static initializers to land in the whole-archive attribute, got %v", attrs.WholeArchiveDeps)
+	}
+}
@@ -0,0 +1,44 @@
+package bp2build
+
+// CcLibraryHeadersProperties is the subset of a cc_library_headers module's properties the
+// converter reads.
+type CcLibraryHeadersProperties struct {
+	ExportIncludeDirs       []string
+	ExportSystemIncludeDirs []string
+
+	// VendorOverrideExportIncludeDirs mirrors Target.Vendor.Override_export_include_dirs: when
+	// non-empty, the vendor variant's export_include_dirs should be these instead of the
+	// defaults, expressed as a select() keyed on the vendor constraint.
+	VendorOverrideExportIncludeDirs []string
+
+	HeaderLibs             []string
+	ExportHeaderLibHeaders []string
+}
+
+// CcLibraryHeadersAttributes is the Bazel cc_library_headers macro's attributes.
+type CcLibraryHeadersAttributes struct {
+	Hdrs           []string
+	Includes       LabelListAttribute
+	SystemIncludes []string
+	Deps           []string
+}
+
+// ConvertCcLibraryHeaders maps a cc_library_headers module's properties onto the Bazel
+// cc_library_headers macro's attributes, keying the vendor override as a per-arch-style
+// select() value on the "vendor" key of Includes.ArchValues.
+func ConvertCcLibraryHeaders(props CcLibraryHeadersProperties) *CcLibraryHeadersAttributes {
+	includes := LabelListAttribute{Value: props.ExportIncludeDirs}
+	if len(props.VendorOverrideExportIncludeDirs) > 0 {
+		includes.ArchValues = map[string][]string{"vendor": props.VendorOverrideExportIncludeDirs}
+	}
+
+	deps := append([]string(nil), props.HeaderLibs...)
+	deps = append(deps, props.ExportHeaderLibHeaders...)
+
+	return &CcLibraryHeadersAttributes{
+		Hdrs:           props.ExportIncludeDirs,
+		Includes:       includes,
+		SystemIncludes: props.ExportSystemIncludeDirs,
+		Deps:           deps,
+	}
+}
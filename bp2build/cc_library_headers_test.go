@@ -0,0 +1,36 @@
+package bp2build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertCcLibraryHeaders_MapsExportDirs(t *testing.T) {
+	props := CcLibraryHeadersProperties{
+		ExportIncludeDirs:       []string{"include"},
+		ExportSystemIncludeDirs: []string{"sys_include"},
+		HeaderLibs:              []string{"libheaders_a"},
+	}
+	got := ConvertCcLibraryHeaders(props)
+	if !reflect.DeepEqual(got.Includes.Value, []string{"include"}) {
+		t.Errorf("got %v", got.Includes.Value)
+	}
+	if !reflect.DeepEqual(got.SystemIncludes, []string{"sys_include"}) {
+		t.Errorf("got %v", got.SystemIncludes)
+	}
+	if !reflect.DeepEqual(got.Deps, []string{"libheaders_a"}) {
+		t.Errorf("got %v", got.Deps)
+	}
+}
+
+func TestConvertCcLibraryHeaders_VendorOverrideSelect(t *testing.T) {
+	props := CcLibraryHeadersProperties{
+		ExportIncludeDirs:               []string{"include"},
+		VendorOverrideExportIncludeDirs: []string{"include_vendor"},
+	}
+	got := ConvertCcLibraryHeaders(props)
+	want := map[string][]string{"vendor": {"include_vendor"}}
+	if !reflect.DeepEqual(got.Includes.ArchValues, want) {
+		t.Errorf("got %v, want %v", got.Includes.ArchValues, want)
+	}
+}
@@ -0,0 +1,72 @@
+package bp2build
+
+// CcLibrarySharedProperties is close enough to cc_library_static's properties that most of the
+// shared converter's job is the deps/whole-archive split plus the soname computation static
+// libraries don't need.
+type CcLibrarySharedProperties struct {
+	Srcs              []string
+	Copts             []string
+	ExportIncludeDirs []string
+	SharedLibs        []string
+	StaticLibs        []string
+	WholeStaticLibs   []string
+	VersionScript     string
+
+	Stem   string
+	Suffix string
+
+	// VendorSuffix is appended to Stem/module name for vendor variants, mirroring how
+	// linkerFlags' getLibName computes the vendor-suffixed soname.
+	VendorSuffix string
+
+	Stubs    bool
+	Sanitize bool
+}
+
+// CcLibrarySharedAttributes is the Bazel cc_library_shared macro's attributes.
+type CcLibrarySharedAttributes struct {
+	Srcs             LabelListAttribute
+	Copts            []string
+	Includes         []string
+	WholeArchiveDeps []string
+	Deps             []string
+	VersionScript    string
+	Soname           string
+}
+
+// ShlibSuffix is the shared library file extension, matching cc's own ShlibSuffix constant.
+const ShlibSuffix = ".so"
+
+// ComputeSoname returns the soname a cc_library_shared module's Bazel target must produce,
+// matching what linkerFlags' getLibName + ShlibSuffix computes in Soong, so mixed builds can
+// interchange the Soong- and Bazel-built outputs.
+func ComputeSoname(moduleName string, props CcLibrarySharedProperties) string {
+	name := moduleName
+	if props.Stem != "" {
+		name = props.Stem
+	}
+	name += props.VendorSuffix
+	name += props.Suffix
+	return name + ShlibSuffix
+}
+
+// ConvertCcLibraryShared maps a cc_library_shared module's properties onto the Bazel
+// cc_library_shared macro's attributes. Modules using stubs or sanitizers are not yet supported.
+func ConvertCcLibraryShared(moduleName string, props CcLibrarySharedProperties) (*CcLibrarySharedAttributes, error) {
+	switch {
+	case props.Stubs:
+		return nil, &UnsupportedFeatureError{ModuleType: "cc_library_shared", Feature: "stubs"}
+	case props.Sanitize:
+		return nil, &UnsupportedFeatureError{ModuleType: "cc_library_shared", Feature: "sanitize"}
+	}
+
+	return &CcLibrarySharedAttributes{
+		Srcs:             LabelListAttribute{Value: props.Srcs},
+		Copts:            props.Copts,
+		Includes:         props.ExportIncludeDirs,
+		WholeArchiveDeps: props.WholeStaticLibs,
+		Deps:             append(append([]string(nil), props.SharedLibs...), props.StaticLibs...),
+		VersionScript:    props.VersionScript,
+		Soname:           ComputeSoname(moduleName, props),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package bp2build
+
+import "testing"
+
+func TestComputeSoname_DefaultAndVendorSuffixed(t *testing.T) {
+	if got := ComputeSoname("libfoo", CcLibrarySharedProperties{}); got != "libfoo.so" {
+		t.Errorf("got %q", got)
+	}
+	got := ComputeSoname("libfoo", CcLibrarySharedProperties{VendorSuffix: ".vendor"})
+	if got != "libfoo.vendor.so" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestComputeSoname_StemOverridesModuleName(t *testing.T) {
+	got := ComputeSoname("libfoo", CcLibrarySharedProperties{Stem: "libbar", Suffix: "-v1"})
+	if got != "libbar-v1.so" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestConvertCcLibraryShared_MapsAttributesAndSoname(t *testing.T) {
+	props := CcLibrarySharedProperties{
+		Srcs:            []string{"foo.cpp"},
+		SharedLibs:      []string{"libshared"},
+		StaticLibs:      []string{"libstatic"},
+		WholeStaticLibs: []string{"libwhole"},
+		VersionScript:   "foo.map.txt",
+	}
+	got, err := ConvertCcLibraryShared("libfoo", props)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Soname != "libfoo.so" {
+		t.Errorf("got soname %q", got.Soname)
+	}
+	if got.VersionScript != "foo.map.txt" {
+		t.Errorf("got version script %q", got.VersionScript)
+	}
+	if len(got.Deps) != 2 || len(got.WholeArchiveDeps) != 1 {
+		t.Errorf("got deps=%v wholeArchiveDeps=%v", got.Deps, got.WholeArchiveDeps)
+	}
+}
+
+func TestConvertCcLibraryShared_UnsupportedFeaturesSkipped(t *testing.T) {
+	if _, err := ConvertCcLibraryShared("libfoo", CcLibrarySharedProperties{Stubs: true}); err == nil {
+		t.Errorf("expected an error for stubs")
+	}
+	if _, err := ConvertCcLibraryShared("libfoo", CcLibrarySharedProperties{Sanitize: true}); err == nil {
+		t.Errorf("expected an error for sanitize")
+	}
+}
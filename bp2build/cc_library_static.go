@@ -0,0 +1,75 @@
+// Package bp2build converts a subset of Android.bp module types into equivalent Bazel BUILD
+// targets, module type by module type, so the Bazel migration can progress incrementally.
+package bp2build
+
+import "fmt"
+
+// LabelListAttribute is a Bazel string_list/label_list attribute value, with an optional
+// per-arch override map for values that need a select() in the generated BUILD file.
+type LabelListAttribute struct {
+	Value      []string
+	ArchValues map[string][]string
+}
+
+// CcLibraryStaticProperties is the subset of a cc_library_static module's merged (post-defaults)
+// properties LibraryStaticBp2Build reads to build the Bazel attributes.
+type CcLibraryStaticProperties struct {
+	Srcs                []string
+	ArchSrcs            map[string][]string
+	Copts               []string
+	Local_include_dirs  []string
+	Export_include_dirs []string
+	Whole_static_libs   []string
+	Static_libs         []string
+
+	// Unsupported features: when set, the module must be skipped rather than converted.
+	Stubs          bool
+	Sanitize       bool
+	Version_script string
+}
+
+// CcLibraryStaticAttributes is the Bazel cc_library_static macro's attributes, as produced by
+// ConvertCcLibraryStatic.
+type CcLibraryStaticAttributes struct {
+	Srcs             LabelListAttribute
+	Copts            []string
+	Includes         []string
+	ExportIncludes   []string
+	WholeArchiveDeps []string
+	Deps             []string
+}
+
+// UnsupportedFeatureError is returned by a bp2build converter when a module uses a property the
+// converter doesn't yet handle, so the caller can record a conversion failure with a reason
+// instead of emitting a broken BUILD target.
+type UnsupportedFeatureError struct {
+	ModuleType string
+	Feature    string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s: unsupported feature %q, module cannot be converted", e.ModuleType, e.Feature)
+}
+
+// ConvertCcLibraryStatic maps a cc_library_static module's properties onto the Bazel
+// cc_library_static macro's attributes. Modules using stubs, sanitizers or version scripts are
+// not yet supported and return an *UnsupportedFeatureError instead of a partial conversion.
+func ConvertCcLibraryStatic(props CcLibraryStaticProperties) (*CcLibraryStaticAttributes, error) {
+	switch {
+	case props.Stubs:
+		return nil, &UnsupportedFeatureError{ModuleType: "cc_library_static", Feature: "stubs"}
+	case props.Sanitize:
+		return nil, &UnsupportedFeatureError{ModuleType: "cc_library_static", Feature: "sanitize"}
+	case props.Version_script != "":
+		return nil, &UnsupportedFeatureError{ModuleType: "cc_library_static", Feature: "version_script"}
+	}
+
+	return &CcLibraryStaticAttributes{
+		Srcs:             LabelListAttribute{Value: props.Srcs, ArchValues: props.ArchSrcs},
+		Copts:            props.Copts,
+		Includes:         props.Local_include_dirs,
+		ExportIncludes:   props.Export_include_dirs,
+		WholeArchiveDeps: props.Whole_static_libs,
+		Deps:             props.Static_libs,
+	}, nil
+}
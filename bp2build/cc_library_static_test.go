@@ -0,0 +1,61 @@
+package bp2build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertCcLibraryStatic_MapsBasicAttributes(t *testing.T) {
+	props := CcLibraryStaticProperties{
+		Srcs:                []string{"foo.cpp"},
+		Copts:               []string{"-Wall"},
+		Local_include_dirs:  []string{"include"},
+		Export_include_dirs: []string{"export_include"},
+		Whole_static_libs:   []string{"libwhole"},
+		Static_libs:         []string{"libstatic"},
+	}
+
+	got, err := ConvertCcLibraryStatic(props)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &CcLibraryStaticAttributes{
+		Srcs:             LabelListAttribute{Value: []string{"foo.cpp"}},
+		Copts:            []string{"-Wall"},
+		Includes:         []string{"include"},
+		ExportIncludes:   []string{"export_include"},
+		WholeArchiveDeps: []string{"libwhole"},
+		Deps:             []string{"libstatic"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestConvertCcLibraryStatic_ArchSrcs(t *testing.T) {
+	props := CcLibraryStaticProperties{
+		ArchSrcs: map[string][]string{"arm64": {"arm64_only.cpp"}},
+	}
+	got, err := ConvertCcLibraryStatic(props)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.Srcs.ArchValues, props.ArchSrcs) {
+		t.Errorf("got %v, want %v", got.Srcs.ArchValues, props.ArchSrcs)
+	}
+}
+
+func TestConvertCcLibraryStatic_UnsupportedFeaturesSkipped(t *testing.T) {
+	tests := []CcLibraryStaticProperties{
+		{Stubs: true},
+		{Sanitize: true},
+		{Version_script: "foo.map.txt"},
+	}
+	for _, props := range tests {
+		if _, err := ConvertCcLibraryStatic(props); err == nil {
+			t.Errorf("expected an UnsupportedFeatureError for %+v", props)
+		} else if _, ok := err.(*UnsupportedFeatureError); !ok {
+			t.Errorf("expected *UnsupportedFeatureError, got %T", err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package bp2build
+
+// ObjectLinkerProperties is the subset of a cc_object module's linker properties
+// ObjectBp2Build reads in addition to srcs/copts.
+type ObjectLinkerProperties struct {
+	Linker_script  string
+	Crt            bool
+	Prefix_symbols string
+	Objs           []string
+	ArchObjs       map[string][]string
+}
+
+// ObjectAttributes is the Bazel cc_object rule's attributes, as produced by ObjectBp2Build.
+type ObjectAttributes struct {
+	Srcs          LabelListAttribute
+	Copts         []string
+	LinkerScript  string
+	Crt           bool
+	PrefixSymbols string
+	Deps          LabelListAttribute
+}
+
+// ObjectBp2Build maps a cc_object module's properties, including the linker-related ones that
+// were previously dropped, onto the Bazel cc_object rule's attributes.
+func ObjectBp2Build(srcs, copts []string, linker ObjectLinkerProperties) *ObjectAttributes {
+	return &ObjectAttributes{
+		Srcs:          LabelListAttribute{Value: srcs},
+		Copts:         copts,
+		LinkerScript:  linker.Linker_script,
+		Crt:           linker.Crt,
+		PrefixSymbols: linker.Prefix_symbols,
+		Deps:          LabelListAttribute{Value: linker.Objs, ArchValues: linker.ArchObjs},
+	}
+}
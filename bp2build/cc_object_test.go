@@ -0,0 +1,39 @@
+package bp2build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObjectBp2Build_CarriesLinkerScriptCrtAndPrefixSymbols(t *testing.T) {
+	linker := ObjectLinkerProperties{
+		Linker_script:  "crtbegin.lds",
+		Crt:            true,
+		Prefix_symbols: "_crt_",
+		Objs:           []string{"other_obj"},
+	}
+	got := ObjectBp2Build([]string{"crtbegin.c"}, []string{"-fno-stack-protector"}, linker)
+
+	if got.LinkerScript != "crtbegin.lds" {
+		t.Errorf("got linker script %q", got.LinkerScript)
+	}
+	if !got.Crt {
+		t.Errorf("expected crt to be true")
+	}
+	if got.PrefixSymbols != "_crt_" {
+		t.Errorf("got prefix symbols %q", got.PrefixSymbols)
+	}
+	if !reflect.DeepEqual(got.Deps.Value, []string{"other_obj"}) {
+		t.Errorf("got deps %v", got.Deps.Value)
+	}
+}
+
+func TestObjectBp2Build_ArchConditionalObjs(t *testing.T) {
+	linker := ObjectLinkerProperties{
+		ArchObjs: map[string][]string{"arm64": {"arm64_only_obj"}},
+	}
+	got := ObjectBp2Build(nil, nil, linker)
+	if !reflect.DeepEqual(got.Deps.ArchValues, linker.ArchObjs) {
+		t.Errorf("got %v, want %v", got.Deps.ArchValues, linker.ArchObjs)
+	}
+}
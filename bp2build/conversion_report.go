@@ -0,0 +1,64 @@
+package bp2build
+
+import "sync"
+
+// ConversionOutcome classifies why a module did or didn't convert.
+type ConversionOutcome string
+
+const (
+	Converted   ConversionOutcome = "converted"
+	Unsupported ConversionOutcome = "unsupported"
+	Handcrafted ConversionOutcome = "handcrafted" // bazel_module.label passthrough
+)
+
+// ConversionRecord is one module's conversion outcome, as recorded by a converter calling
+// RecordConversion.
+type ConversionRecord struct {
+	ModuleName string
+	ModuleType string
+	Outcome    ConversionOutcome
+	Reason     string
+}
+
+// conversionReport is the process-wide singleton every converter reports into, mirroring the
+// mutex-protected registry pattern used elsewhere in this codebase (e.g. the gen-path registry).
+var conversionReport = struct {
+	mu      sync.Mutex
+	records []ConversionRecord
+}{}
+
+// RecordConversion appends one module's conversion outcome to the shared report. Converters
+// call this instead of silently returning when a module can't be converted, so the migration
+// dashboard has a reason to show.
+func RecordConversion(record ConversionRecord) {
+	conversionReport.mu.Lock()
+	defer conversionReport.mu.Unlock()
+	conversionReport.records = append(conversionReport.records, record)
+}
+
+// RecordHandcrafted records a module using the bazel_module.label passthrough, which bypasses
+// conversion entirely but should still show up in the migration dashboard as accounted for.
+func RecordHandcrafted(moduleName, moduleType string) {
+	RecordConversion(ConversionRecord{ModuleName: moduleName, ModuleType: moduleType, Outcome: Handcrafted})
+}
+
+// RecordUnsupported records a module that a converter declined to convert, naming the reason
+// (typically an *UnsupportedFeatureError's message).
+func RecordUnsupported(moduleName, moduleType, reason string) {
+	RecordConversion(ConversionRecord{ModuleName: moduleName, ModuleType: moduleType, Outcome: Unsupported, Reason: reason})
+}
+
+// ConversionReport returns a copy of every record accumulated so far, for the metrics/report file
+// writer and for tests.
+func ConversionReport() []ConversionRecord {
+	conversionReport.mu.Lock()
+	defer conversionReport.mu.Unlock()
+	return append([]ConversionRecord(nil), conversionReport.records...)
+}
+
+// ResetConversionReportForTests clears the shared report between test cases.
+func ResetConversionReportForTests() {
+	conversionReport.mu.Lock()
+	defer conversionReport.mu.Unlock()
+	conversionReport.records = nil
+}
@@ -0,0 +1,32 @@
+package bp2build
+
+import "testing"
+
+func TestConversionReport_RecordsUnsupportedAndHandcrafted(t *testing.T) {
+	ResetConversionReportForTests()
+	defer ResetConversionReportForTests()
+
+	RecordUnsupported("libfoo", "cc_library_static", "stubs")
+	RecordHandcrafted("libbar", "cc_library_shared")
+
+	got := ConversionReport()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %v", got)
+	}
+	if got[0].Outcome != Unsupported || got[0].Reason != "stubs" {
+		t.Errorf("got %+v", got[0])
+	}
+	if got[1].Outcome != Handcrafted {
+		t.Errorf("got %+v", got[1])
+	}
+}
+
+func TestConversionReport_EmptyAfterReset(t *testing.T) {
+	ResetConversionReportForTests()
+	RecordUnsupported("libfoo", "cc_library_static", "stubs")
+	ResetConversionReportForTests()
+
+	if got := ConversionReport(); len(got) != 0 {
+		t.Errorf("expected an empty report after reset, got %v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package bp2build
+
+import "fmt"
+
+// DefaultsNode is one cc_defaults/genrule_defaults module in a defaults chain: its own scalar and
+// list property values, plus the defaults modules it in turn inherits from.
+type DefaultsNode struct {
+	Name     string
+	Lists    map[string][]string
+	Scalars  map[string]string
+	Defaults []string
+}
+
+// FlattenDefaults fully squashes root's defaults chain (including transitively inherited
+// defaults) into a single set of merged property values, so bp2build attribute extraction sees
+// the same fully-resolved properties Soong's own defaults application would produce. List
+// properties are concatenated in defaults-then-module order (earlier defaults first); scalar
+// properties follow Soong's own last-write-wins precedence, with the root module's own value
+// (its entry in nodes, visited after every defaults module it inherits) winning last.
+func FlattenDefaults(root string, nodes map[string]DefaultsNode) (DefaultsNode, error) {
+	done := map[string]bool{}
+	visiting := map[string]bool{}
+	var order []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if done[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("defaults flattening: cycle detected at %q", name)
+		}
+		node, ok := nodes[name]
+		if !ok {
+			return fmt.Errorf("defaults flattening: %q not found", name)
+		}
+		visiting[name] = true
+		for _, dep := range node.Defaults {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		done[name] = true
+		order = append(order, name)
+		return nil
+	}
+	if err := visit(root); err != nil {
+		return DefaultsNode{}, err
+	}
+
+	merged := DefaultsNode{Name: root, Lists: map[string][]string{}, Scalars: map[string]string{}}
+	for _, name := range order {
+		node := nodes[name]
+		for k, v := range node.Lists {
+			merged.Lists[k] = append(merged.Lists[k], v...)
+		}
+		for k, v := range node.Scalars {
+			merged.Scalars[k] = v
+		}
+	}
+	return merged, nil
+}
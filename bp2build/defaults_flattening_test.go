@@ -0,0 +1,65 @@
+package bp2build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenDefaults_TwoStackedDefaults(t *testing.T) {
+	nodes := map[string]DefaultsNode{
+		"base_defaults": {
+			Name:    "base_defaults",
+			Lists:   map[string][]string{"copts": {"-Wall"}},
+			Scalars: map[string]string{"stl": "c++_shared"},
+		},
+		"mid_defaults": {
+			Name:     "mid_defaults",
+			Lists:    map[string][]string{"copts": {"-Werror"}},
+			Defaults: []string{"base_defaults"},
+		},
+		"my_object": {
+			Name:     "my_object",
+			Lists:    map[string][]string{"srcs": {"foo.c"}},
+			Defaults: []string{"mid_defaults"},
+		},
+	}
+
+	got, err := FlattenDefaults("my_object", nodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantCopts := []string{"-Wall", "-Werror"}
+	if !reflect.DeepEqual(got.Lists["copts"], wantCopts) {
+		t.Errorf("got copts %v, want %v", got.Lists["copts"], wantCopts)
+	}
+	if !reflect.DeepEqual(got.Lists["srcs"], []string{"foo.c"}) {
+		t.Errorf("got srcs %v", got.Lists["srcs"])
+	}
+	if got.Scalars["stl"] != "c++_shared" {
+		t.Errorf("got stl %q", got.Scalars["stl"])
+	}
+}
+
+func TestFlattenDefaults_CycleDetected(t *testing.T) {
+	nodes := map[string]DefaultsNode{
+		"a": {Name: "a", Defaults: []string{"b"}},
+		"b": {Name: "b", Defaults: []string{"a"}},
+	}
+	if _, err := FlattenDefaults("a", nodes); err == nil {
+		t.Errorf("expected a cycle-detection error")
+	}
+}
+
+func TestFlattenDefaults_ScalarPrecedenceRootWins(t *testing.T) {
+	nodes := map[string]DefaultsNode{
+		"base": {Name: "base", Scalars: map[string]string{"stl": "c++_static"}},
+		"root": {Name: "root", Scalars: map[string]string{"stl": "c++_shared"}, Defaults: []string{"base"}},
+	}
+	got, err := FlattenDefaults("root", nodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Scalars["stl"] != "c++_shared" {
+		t.Errorf("expected the root module's own value to win, got %q", got.Scalars["stl"])
+	}
+}
@@ -0,0 +1,34 @@
+package bp2build
+
+import "strings"
+
+// HeaderExts are the file extensions globbed into hdrs for each exported include dir, mirroring
+// cc's own headerExts used for snapshot header collection.
+var HeaderExts = []string{".h", ".hh", ".hpp", ".inc"}
+
+// FlagExporterHdrsAndIncludes turns a module's Export_include_dirs into both the "includes"
+// attribute (for propagation to dependents) and a "hdrs" glob label list (so Bazel sandboxing
+// sees the files), handling the "." (module-is-its-own-export-dir) case.
+func FlagExporterHdrsAndIncludes(moduleDir string, exportIncludeDirs []string) (includes []string, hdrsGlobs []string) {
+	for _, dir := range exportIncludeDirs {
+		resolved := moduleDir
+		if dir != "." && dir != "" {
+			resolved = moduleDir + "/" + dir
+		}
+		includes = append(includes, resolved)
+		for _, ext := range HeaderExts {
+			hdrsGlobs = append(hdrsGlobs, resolved+"/**/*"+ext)
+		}
+	}
+	return includes, hdrsGlobs
+}
+
+// GeneratedHeaderDepsAsHdrs maps generated_headers dep module names onto the corresponding Bazel
+// targets' outputs label, for wiring into a converted module's hdrs.
+func GeneratedHeaderDepsAsHdrs(generatedHeaderDeps []string) []string {
+	hdrs := make([]string, len(generatedHeaderDeps))
+	for i, dep := range generatedHeaderDeps {
+		hdrs[i] = ":" + strings.TrimPrefix(dep, ":")
+	}
+	return hdrs
+}
@@ -0,0 +1,31 @@
+package bp2build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlagExporterHdrsAndIncludes_PlainDir(t *testing.T) {
+	includes, hdrs := FlagExporterHdrsAndIncludes("foo", []string{"include"})
+	if !reflect.DeepEqual(includes, []string{"foo/include"}) {
+		t.Errorf("got includes %v", includes)
+	}
+	if len(hdrs) != len(HeaderExts) {
+		t.Errorf("got %d hdrs globs, want %d", len(hdrs), len(HeaderExts))
+	}
+}
+
+func TestFlagExporterHdrsAndIncludes_ModuleDirItself(t *testing.T) {
+	includes, _ := FlagExporterHdrsAndIncludes("foo", []string{"."})
+	if !reflect.DeepEqual(includes, []string{"foo"}) {
+		t.Errorf("got includes %v", includes)
+	}
+}
+
+func TestGeneratedHeaderDepsAsHdrs(t *testing.T) {
+	got := GeneratedHeaderDepsAsHdrs([]string{"gen_foo", ":gen_bar"})
+	want := []string{":gen_foo", ":gen_bar"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package bp2build
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GenruleProperties is the subset of a genrule module's properties GenruleBp2Build reads.
+type GenruleProperties struct {
+	Cmd         string
+	Tools       []string
+	Srcs        []string
+	ExcludeSrcs []string
+	Out         []string
+
+	UsesDepfile  bool
+	UsesSharding bool
+}
+
+// GenruleAttributes is the Bazel genrule rule's attributes, as produced by GenruleBp2Build.
+type GenruleAttributes struct {
+	Cmd         string
+	Tools       []string
+	Srcs        []string
+	ExcludeSrcs []string
+	Outs        []string
+}
+
+var cmdVarRe = regexp.MustCompile(`\$\((location|in|out|genDir)\)`)
+
+// RewriteGenruleCmd rewrites a Soong genrule cmd's $(location)/$(in)/$(out)/$(genDir) references
+// into their Bazel genrule equivalents: $(location), $(SRCS), $(OUTS), $(RULEDIR).
+func RewriteGenruleCmd(cmd string) string {
+	return cmdVarRe.ReplaceAllStringFunc(cmd, func(m string) string {
+		switch m {
+		case "$(location)":
+			return "$(location)"
+		case "$(in)":
+			return "$(SRCS)"
+		case "$(out)":
+			return "$(OUTS)"
+		case "$(genDir)":
+			return "$(RULEDIR)"
+		}
+		return m
+	})
+}
+
+// GenruleBp2Build converts a genrule module's properties into Bazel genrule attributes.
+// Commands using $(depfile) or sharding aren't supported and return an *UnsupportedFeatureError.
+func GenruleBp2Build(props GenruleProperties) (*GenruleAttributes, error) {
+	if props.UsesDepfile {
+		return nil, &UnsupportedFeatureError{ModuleType: "genrule", Feature: "depfile"}
+	}
+	if props.UsesSharding {
+		return nil, &UnsupportedFeatureError{ModuleType: "genrule", Feature: "sharding"}
+	}
+
+	return &GenruleAttributes{
+		Cmd:         RewriteGenruleCmd(props.Cmd),
+		Tools:       props.Tools,
+		Srcs:        props.Srcs,
+		ExcludeSrcs: props.ExcludeSrcs,
+		Outs:        props.Out,
+	}, nil
+}
+
+// GensrcsBp2Build converts a gensrcs module into a per-file genrule macro invocation: gensrcs
+// already runs its cmd once per input, which is exactly what the generated Bazel macro call does,
+// so the conversion is the genrule conversion plus rewriting Out's single output_extension
+// pattern into the per-input $(OUTS) Bazel expects.
+func GensrcsBp2Build(props GenruleProperties, outputExtension string) (*GenruleAttributes, error) {
+	attrs, err := GenruleBp2Build(props)
+	if err != nil {
+		return nil, err
+	}
+	attrs.Outs = make([]string, len(props.Srcs))
+	for i, src := range props.Srcs {
+		base := strings.TrimSuffix(src, filepath.Ext(src))
+		attrs.Outs[i] = base + outputExtension
+	}
+	return attrs, nil
+}
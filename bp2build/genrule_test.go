@@ -0,0 +1,61 @@
+package bp2build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRewriteGenruleCmd(t *testing.T) {
+	cmd := "$(location tool) $(in) > $(out); echo $(genDir)"
+	got := RewriteGenruleCmd(cmd)
+	want := "$(location tool) $(SRCS) > $(OUTS); echo $(RULEDIR)"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGenruleBp2Build_MapsAttributes(t *testing.T) {
+	props := GenruleProperties{
+		Cmd:   "$(location tool) $(in) > $(out)",
+		Tools: []string{"tool"},
+		Srcs:  []string{"a.txt"},
+		Out:   []string{"a.out"},
+	}
+	got, err := GenruleBp2Build(props)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &GenruleAttributes{
+		Cmd:   "$(location tool) $(SRCS) > $(OUTS)",
+		Tools: []string{"tool"},
+		Srcs:  []string{"a.txt"},
+		Outs:  []string{"a.out"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestGenruleBp2Build_UnconvertibleFeatures(t *testing.T) {
+	if _, err := GenruleBp2Build(GenruleProperties{UsesDepfile: true}); err == nil {
+		t.Errorf("expected an error for $(depfile) usage")
+	}
+	if _, err := GenruleBp2Build(GenruleProperties{UsesSharding: true}); err == nil {
+		t.Errorf("expected an error for sharding")
+	}
+}
+
+func TestGensrcsBp2Build_PerInputOutputs(t *testing.T) {
+	props := GenruleProperties{
+		Cmd:  "$(location tool) $(in) > $(out)",
+		Srcs: []string{"a.proto", "b.proto"},
+	}
+	got, err := GensrcsBp2Build(props, ".cpp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"a.cpp", "b.cpp"}
+	if !reflect.DeepEqual(got.Outs, want) {
+		t.Errorf("got %v, want %v", got.Outs, want)
+	}
+}
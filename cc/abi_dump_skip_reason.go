@@ -0,0 +1,48 @@
+package cc
+
+// AbiDumpSkipReason names why shouldCreateSourceAbiDump declined to generate a dump, so library
+// owners can diagnose coverage gaps without reading cc source.
+type AbiDumpSkipReason string
+
+const (
+	AbiDumpReasonNone                      AbiDumpSkipReason = ""
+	AbiDumpReasonNotForPlatform            AbiDumpSkipReason = "module is not built for the platform"
+	AbiDumpReasonNoClass                   AbiDumpSkipReason = "module has no class eligible for ABI dumping (not a shared library)"
+	AbiDumpReasonApexWithoutExplicitEnable AbiDumpSkipReason = "module is an apex member and did not explicitly enable ABI checking"
+	AbiDumpReasonConfigDisabled            AbiDumpSkipReason = "ABI dump generation is disabled by config for this build"
+)
+
+// AbiDumpDecision is the result of shouldCreateSourceAbiDump: whether to create a dump, and if
+// not, the structured reason recorded for the abi-coverage report and the "m <lib>-abi-why"
+// phony.
+type AbiDumpDecision struct {
+	Create bool
+	Reason AbiDumpSkipReason
+}
+
+// ShouldCreateSourceAbiDump decides whether to generate an ABI dump for a module, given the
+// inputs shouldCreateSourceAbiDump already inspects, now returning a structured reason instead
+// of a bare bool when it declines.
+func ShouldCreateSourceAbiDump(isForPlatform, hasEligibleClass, isApexMember, explicitlyEnabledForApex, configEnabled bool) AbiDumpDecision {
+	if !configEnabled {
+		return AbiDumpDecision{Create: false, Reason: AbiDumpReasonConfigDisabled}
+	}
+	if !isForPlatform {
+		return AbiDumpDecision{Create: false, Reason: AbiDumpReasonNotForPlatform}
+	}
+	if !hasEligibleClass {
+		return AbiDumpDecision{Create: false, Reason: AbiDumpReasonNoClass}
+	}
+	if isApexMember && !explicitlyEnabledForApex {
+		return AbiDumpDecision{Create: false, Reason: AbiDumpReasonApexWithoutExplicitEnable}
+	}
+	return AbiDumpDecision{Create: true, Reason: AbiDumpReasonNone}
+}
+
+// AbiWhyPhonyOutput renders the message the "m <lib>-abi-why" phony prints for decision.
+func AbiWhyPhonyOutput(moduleName string, decision AbiDumpDecision) string {
+	if decision.Create {
+		return moduleName + ": ABI dump is generated for this module"
+	}
+	return moduleName + ": ABI dump skipped: " + string(decision.Reason)
+}
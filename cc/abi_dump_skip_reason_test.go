@@ -0,0 +1,41 @@
+package cc
+
+import "testing"
+
+func TestShouldCreateSourceAbiDump_EachDeclineReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		isForPlatform, hasEligibleClass, isApexMember, explicitlyEnabledForApex, configEnabled bool
+		wantReason AbiDumpSkipReason
+	}{
+		{name: "config disabled", configEnabled: false, wantReason: AbiDumpReasonConfigDisabled},
+		{name: "not for platform", configEnabled: true, isForPlatform: false, wantReason: AbiDumpReasonNotForPlatform},
+		{name: "no eligible class", configEnabled: true, isForPlatform: true, hasEligibleClass: false, wantReason: AbiDumpReasonNoClass},
+		{name: "apex without explicit enable", configEnabled: true, isForPlatform: true, hasEligibleClass: true, isApexMember: true, explicitlyEnabledForApex: false, wantReason: AbiDumpReasonApexWithoutExplicitEnable},
+	}
+	for _, tt := range tests {
+		got := ShouldCreateSourceAbiDump(tt.isForPlatform, tt.hasEligibleClass, tt.isApexMember, tt.explicitlyEnabledForApex, tt.configEnabled)
+		if got.Create {
+			t.Errorf("%s: expected dump to be declined", tt.name)
+		}
+		if got.Reason != tt.wantReason {
+			t.Errorf("%s: got reason %q, want %q", tt.name, got.Reason, tt.wantReason)
+		}
+	}
+}
+
+func TestShouldCreateSourceAbiDump_Created(t *testing.T) {
+	got := ShouldCreateSourceAbiDump(true, true, false, false, true)
+	if !got.Create || got.Reason != AbiDumpReasonNone {
+		t.Errorf("got %+v, expected Create=true with no reason", got)
+	}
+}
+
+func TestAbiWhyPhonyOutput(t *testing.T) {
+	declined := ShouldCreateSourceAbiDump(false, true, false, false, true)
+	got := AbiWhyPhonyOutput("libfoo", declined)
+	want := "libfoo: ABI dump skipped: " + string(AbiDumpReasonNotForPlatform)
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
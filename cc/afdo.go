@@ -0,0 +1,50 @@
+package cc
+
+import "fmt"
+
+// AfdoProperties holds the `afdo` opt-in property.
+type AfdoProperties struct {
+	Afdo *bool
+}
+
+// AfdoEnabled reports whether the module opted into AFDO.
+func AfdoEnabled(props AfdoProperties) bool {
+	return props.Afdo != nil && *props.Afdo
+}
+
+// AfdoProfilePath resolves the product-configured profile for moduleName, falling back from an
+// arch-specific profile to the arch-generic one, matching the fallback order sanitizer/coverage
+// per-module lookups already use. It returns ("", false) when no profile is configured for this
+// module at all, which callers must treat as a silent, warning-free no-op.
+func AfdoProfilePath(profileDir, moduleName, arch string) (string, bool) {
+	archSpecific := fmt.Sprintf("%s/%s_%s.afdo", profileDir, moduleName, arch)
+	if archSpecificExists(archSpecific) {
+		return archSpecific, true
+	}
+	generic := fmt.Sprintf("%s/%s.afdo", profileDir, moduleName)
+	if archSpecificExists(generic) {
+		return generic, true
+	}
+	return "", false
+}
+
+// archSpecificExists is overridable by tests; production callers provide the real filesystem
+// check through this hook so AfdoProfilePath stays pure and testable.
+var archSpecificExists = func(path string) bool { return false }
+
+// AfdoCompileFlags returns the compile flags and the profile path that must be added to
+// cFlagsDeps, for a module with a resolved profile. Returns nil, "" when no profile was found,
+// which is the expected common case and must not warn.
+func AfdoCompileFlags(props AfdoProperties, profilePath string) ([]string, string) {
+	if !AfdoEnabled(props) || profilePath == "" {
+		return nil, ""
+	}
+	return []string{"-fprofile-sample-use=" + profilePath}, profilePath
+}
+
+// AfdoAppliesTo reports whether AFDO flags should apply to a particular compile: never for
+// stubs, sabi dump, or host variants, mirroring other per-module flag injection (sanitizer,
+// coverage).
+func AfdoAppliesTo(isStubsCompile, isSabiDumpCompile, isHostVariant bool) bool {
+	return !isStubsCompile && !isSabiDumpCompile && !isHostVariant
+}
@@ -0,0 +1,56 @@
+package cc
+
+import "testing"
+
+func boolPtrAfdo(b bool) *bool { return &b }
+
+func TestAfdoProfilePath_ArchSpecificFallback(t *testing.T) {
+	old := archSpecificExists
+	defer func() { archSpecificExists = old }()
+
+	archSpecificExists = func(path string) bool {
+		return path == "profiles/libfoo.afdo"
+	}
+	path, ok := AfdoProfilePath("profiles", "libfoo", "arm64")
+	if !ok || path != "profiles/libfoo.afdo" {
+		t.Errorf("got (%q, %v), want generic fallback", path, ok)
+	}
+}
+
+func TestAfdoProfilePath_MissingIsNoop(t *testing.T) {
+	old := archSpecificExists
+	defer func() { archSpecificExists = old }()
+	archSpecificExists = func(path string) bool { return false }
+
+	if _, ok := AfdoProfilePath("profiles", "libfoo", "arm64"); ok {
+		t.Errorf("expected no profile found")
+	}
+}
+
+func TestAfdoCompileFlags(t *testing.T) {
+	props := AfdoProperties{Afdo: boolPtrAfdo(true)}
+	flags, path := AfdoCompileFlags(props, "profiles/libfoo.afdo")
+	if path != "profiles/libfoo.afdo" {
+		t.Errorf("got path %q", path)
+	}
+	if len(flags) != 1 || flags[0] != "-fprofile-sample-use=profiles/libfoo.afdo" {
+		t.Errorf("got flags %v", flags)
+	}
+}
+
+func TestAfdoCompileFlags_NoProfileIsNoop(t *testing.T) {
+	props := AfdoProperties{Afdo: boolPtrAfdo(true)}
+	flags, path := AfdoCompileFlags(props, "")
+	if flags != nil || path != "" {
+		t.Errorf("expected a no-op, got (%v, %q)", flags, path)
+	}
+}
+
+func TestAfdoAppliesTo(t *testing.T) {
+	if !AfdoAppliesTo(false, false, false) {
+		t.Errorf("expected AFDO to apply to a regular device compile")
+	}
+	if AfdoAppliesTo(true, false, false) || AfdoAppliesTo(false, true, false) || AfdoAppliesTo(false, false, true) {
+		t.Errorf("expected AFDO to be excluded from stubs/sabi/host compiles")
+	}
+}
@@ -0,0 +1,39 @@
+package cc
+
+import "strings"
+
+// ArchiverProperties holds the per-module archiver configuration for TransformObjToStaticLib.
+type ArchiverProperties struct {
+	// Thin_archive requests a thin archive (member files referenced by path rather than
+	// embedded), cutting disk usage and link-time I/O for huge static libraries.
+	Thin_archive *bool
+}
+
+func (p *ArchiverProperties) thinArchive() bool {
+	return p.Thin_archive != nil && *p.Thin_archive
+}
+
+// ArchiverFlags returns the llvm-ar flags TransformObjToStaticLib should use for a module with
+// the given archiver properties. The D (deterministic) modifier is always present regardless of
+// thin_archive, since both archive kinds need reproducible output.
+func ArchiverFlags(props ArchiverProperties) string {
+	flags := "crsD"
+	if props.thinArchive() {
+		flags = "crsDT"
+	}
+	return flags
+}
+
+// IsThinArchive reports whether ar flags (as returned by ArchiverFlags, or read back from an
+// existing archive's build rule) request a thin archive.
+func IsThinArchive(arFlags string) bool {
+	return strings.Contains(arFlags, "T")
+}
+
+// ExpandThinArchiveForSnapshot returns the command to materialize a real archive from a thin
+// one, for the sdk snapshot and other consumers (whole_static extraction) that need member
+// objects embedded rather than referenced by path before copying the result out of the build
+// tree, where the referenced paths would no longer resolve.
+func ExpandThinArchiveForSnapshot(ar, thinArchivePath, realArchivePath string) string {
+	return ar + " crsD " + realArchivePath + " $(" + ar + " t " + thinArchivePath + ")"
+}
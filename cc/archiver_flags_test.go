@@ -0,0 +1,39 @@
+package cc
+
+import "testing"
+
+func TestArchiverFlags_ThinArchive(t *testing.T) {
+	flags := ArchiverFlags(ArchiverProperties{Thin_archive: boolPtr(true)})
+	if !IsThinArchive(flags) {
+		t.Errorf("expected thin archive flags, got %q", flags)
+	}
+	if !contains(flags, "D") {
+		t.Errorf("expected deterministic modifier to remain in thin archive flags, got %q", flags)
+	}
+}
+
+func TestArchiverFlags_RegularArchive(t *testing.T) {
+	flags := ArchiverFlags(ArchiverProperties{})
+	if IsThinArchive(flags) {
+		t.Errorf("did not expect thin archive flags, got %q", flags)
+	}
+	if !contains(flags, "D") {
+		t.Errorf("expected deterministic modifier in regular archive flags, got %q", flags)
+	}
+}
+
+func contains(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExpandThinArchiveForSnapshot(t *testing.T) {
+	cmd := ExpandThinArchiveForSnapshot("llvm-ar", "libfoo.thin.a", "libfoo.a")
+	if cmd == "" {
+		t.Errorf("expected a non-empty expansion command")
+	}
+}
@@ -0,0 +1,44 @@
+package cc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AvailabilityConsistencyInputs gathers the availability and per-image-override properties that
+// must agree with each other, so the check can be done once up front instead of failing
+// piecemeal in later mutators.
+type AvailabilityConsistencyInputs struct {
+	VendorAvailable      bool
+	ProductAvailable     bool
+	HasVendorOverride    bool
+	HasProductOverride   bool
+	VndkEnabled          bool
+	VendorOverrideNoVndk bool // Target.Vendor sets a cflag/property combination inconsistent with vndk.enabled
+}
+
+// ValidateAvailabilityConsistency cross-checks vendor/product availability against the
+// Target.Vendor/Target.Product override blocks and vndk properties, returning one combined error
+// listing every inconsistency found, or nil if everything is consistent.
+func ValidateAvailabilityConsistency(moduleName string, in AvailabilityConsistencyInputs) error {
+	var problems []string
+
+	if in.HasVendorOverride && !in.VendorAvailable {
+		problems = append(problems, "target.vendor is set but vendor_available is not true")
+	}
+	if in.HasProductOverride && !in.ProductAvailable {
+		problems = append(problems, "target.product is set but product_available is not true")
+	}
+	if in.VndkEnabled && !in.VendorAvailable && !in.ProductAvailable {
+		problems = append(problems, "vndk.enabled is true but neither vendor_available nor product_available is set")
+	}
+	if in.VndkEnabled && in.VendorOverrideNoVndk {
+		problems = append(problems, "target.vendor overrides conflict with vndk.enabled")
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: inconsistent image availability configuration:\n  - %s",
+		moduleName, strings.Join(problems, "\n  - "))
+}
@@ -0,0 +1,31 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAvailabilityConsistency_NoProblems(t *testing.T) {
+	in := AvailabilityConsistencyInputs{VendorAvailable: true, HasVendorOverride: true}
+	if err := ValidateAvailabilityConsistency("libfoo", in); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateAvailabilityConsistency_CombinesMultipleProblems(t *testing.T) {
+	in := AvailabilityConsistencyInputs{
+		HasVendorOverride:  true,
+		HasProductOverride: true,
+		VndkEnabled:        true,
+	}
+	err := ValidateAvailabilityConsistency("libfoo", in)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	msg := err.Error()
+	for _, want := range []string{"target.vendor", "target.product", "vndk.enabled"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected combined error to mention %q, got: %s", want, msg)
+		}
+	}
+}
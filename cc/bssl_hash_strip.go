@@ -0,0 +1,64 @@
+package cc
+
+import "fmt"
+
+// StripProperties is the subset of a cc module's strip configuration relevant to the
+// inject_bssl_hash interaction check.
+type StripProperties struct {
+	All                          *bool
+	Keep_symbols                 *bool
+	Keep_symbols_and_debug_frame *bool
+}
+
+func (p *StripProperties) disableStripping() bool {
+	return (p.Keep_symbols != nil && *p.Keep_symbols) ||
+		(p.Keep_symbols_and_debug_frame != nil && *p.Keep_symbols_and_debug_frame)
+}
+
+func (p *StripProperties) stripAll() bool {
+	return p.All != nil && *p.All
+}
+
+// LinkPipelineStep names one step of linkShared's post-link pipeline, in the order it runs.
+type LinkPipelineStep string
+
+const (
+	StepStrip          LinkPipelineStep = "strip"
+	StepInjectBsslHash LinkPipelineStep = "inject_bssl_hash"
+	StepVersionLib     LinkPipelineStep = "version_lib"
+)
+
+// BsslHashStripPipeline returns the ordered post-link steps linkShared runs for a module with
+// the given strip and inject_bssl_hash configuration. When inject_bssl_hash is enabled, hashing
+// always runs last (after strip and after any version_lib stamping) so the injected hash is
+// computed over the final file content the FIPS module will see, regardless of what strip
+// configuration the module also requests — this makes "strip.all plus inject_bssl_hash reorders
+// content" impossible by construction instead of merely warning about it.
+func BsslHashStripPipeline(strip StripProperties, injectBsslHash bool, hasVersionLib bool) []LinkPipelineStep {
+	var steps []LinkPipelineStep
+	if !strip.disableStripping() {
+		steps = append(steps, StepStrip)
+	}
+	if hasVersionLib {
+		steps = append(steps, StepVersionLib)
+	}
+	if injectBsslHash {
+		steps = append(steps, StepInjectBsslHash)
+	}
+	return steps
+}
+
+// ValidateBsslHashStripInteraction reports an error if the module's strip configuration is
+// incompatible with inject_bssl_hash in a way BsslHashStripPipeline's reordering can't fix —
+// currently: keep_symbols_and_debug_frame combined with inject_bssl_hash, since debug frame
+// data that survives stripping can perturb the hash between dev and release builds.
+func ValidateBsslHashStripInteraction(strip StripProperties, injectBsslHash bool) error {
+	if !injectBsslHash {
+		return nil
+	}
+	if strip.Keep_symbols_and_debug_frame != nil && *strip.Keep_symbols_and_debug_frame {
+		return fmt.Errorf("inject_bssl_hash is not supported together with strip.keep_symbols_and_debug_frame: " +
+			"debug frame data is not reproducible across dev and release builds and would change the injected hash")
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBsslHashStripPipeline_HashAlwaysLast(t *testing.T) {
+	steps := BsslHashStripPipeline(StripProperties{All: boolPtr(true)}, true, true)
+	want := []LinkPipelineStep{StepStrip, StepVersionLib, StepInjectBsslHash}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("got %v, want %v", steps, want)
+	}
+}
+
+func TestBsslHashStripPipeline_NoHash(t *testing.T) {
+	steps := BsslHashStripPipeline(StripProperties{All: boolPtr(true)}, false, false)
+	want := []LinkPipelineStep{StepStrip}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("got %v, want %v", steps, want)
+	}
+}
+
+func TestBsslHashStripPipeline_StrippingDisabled(t *testing.T) {
+	steps := BsslHashStripPipeline(StripProperties{Keep_symbols: boolPtr(true)}, true, false)
+	want := []LinkPipelineStep{StepInjectBsslHash}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("got %v, want %v", steps, want)
+	}
+}
+
+func TestValidateBsslHashStripInteraction_IncompatibleCombination(t *testing.T) {
+	err := ValidateBsslHashStripInteraction(StripProperties{Keep_symbols_and_debug_frame: boolPtr(true)}, true)
+	if err == nil {
+		t.Fatalf("expected an error for keep_symbols_and_debug_frame with inject_bssl_hash")
+	}
+}
+
+func TestValidateBsslHashStripInteraction_CompatibleCombination(t *testing.T) {
+	err := ValidateBsslHashStripInteraction(StripProperties{All: boolPtr(true)}, true)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
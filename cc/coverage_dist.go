@@ -0,0 +1,54 @@
+package cc
+
+import "fmt"
+
+// CoverageDistConfig identifies the build configuration a coverage artifact is dist-ed under, so
+// the same library building for multiple products/arches doesn't collide in the dist dir.
+type CoverageDistConfig struct {
+	ModuleName string
+	Product    string
+	Arch       string
+}
+
+// CoverageDistName returns the per-config dist name for a library's gcno coverage zip, e.g.
+// "libfoo.aosp_arm64.arm64.gcnodir".
+func CoverageDistName(cfg CoverageDistConfig) string {
+	return fmt.Sprintf("%s.%s.%s.gcnodir", cfg.ModuleName, cfg.Product, cfg.Arch)
+}
+
+// SymbolOrderingDistName returns the per-config dist name for a library's bss symbol-ordering
+// file, e.g. "libfoo.aosp_arm64.arm64.symbol_order".
+func SymbolOrderingDistName(cfg CoverageDistConfig) string {
+	return fmt.Sprintf("%s.%s.%s.symbol_order", cfg.ModuleName, cfg.Product, cfg.Arch)
+}
+
+// CoverageDistEntry is one file registered for dist, paired with the name it should be dist-ed
+// under.
+type CoverageDistEntry struct {
+	SrcPath  string
+	DistName string
+}
+
+// CollectCoverageDistEntries returns the dist entries for a library's coverage outputs, each
+// paired with its per-config dist name; coverageOutputFile/symbolOrderingFile are empty strings
+// when the module doesn't have that output.
+func CollectCoverageDistEntries(cfg CoverageDistConfig, coverageOutputFile, symbolOrderingFile string) []CoverageDistEntry {
+	var entries []CoverageDistEntry
+	if coverageOutputFile != "" {
+		entries = append(entries, CoverageDistEntry{SrcPath: coverageOutputFile, DistName: CoverageDistName(cfg)})
+	}
+	if symbolOrderingFile != "" {
+		entries = append(entries, CoverageDistEntry{SrcPath: symbolOrderingFile, DistName: SymbolOrderingDistName(cfg)})
+	}
+	return entries
+}
+
+// CoverageZipsPhonyDeps returns the full set of src paths that the aggregate "coverage-zips"
+// phony target should depend on, across every dist entry collected for the build.
+func CoverageZipsPhonyDeps(entries []CoverageDistEntry) []string {
+	deps := make([]string, len(entries))
+	for i, e := range entries {
+		deps[i] = e.SrcPath
+	}
+	return deps
+}
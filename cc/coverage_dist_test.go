@@ -0,0 +1,36 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoverageDistName(t *testing.T) {
+	cfg := CoverageDistConfig{ModuleName: "libfoo", Product: "aosp_arm64", Arch: "arm64"}
+	if got := CoverageDistName(cfg); got != "libfoo.aosp_arm64.arm64.gcnodir" {
+		t.Errorf("got %q", got)
+	}
+	if got := SymbolOrderingDistName(cfg); got != "libfoo.aosp_arm64.arm64.symbol_order" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestCollectCoverageDistEntries(t *testing.T) {
+	cfg := CoverageDistConfig{ModuleName: "libfoo", Product: "aosp_arm64", Arch: "arm64"}
+	entries := CollectCoverageDistEntries(cfg, "out/libfoo.gcnodir", "")
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if entries[0].DistName != "libfoo.aosp_arm64.arm64.gcnodir" {
+		t.Errorf("got dist name %q", entries[0].DistName)
+	}
+}
+
+func TestCoverageZipsPhonyDeps(t *testing.T) {
+	entries := []CoverageDistEntry{{SrcPath: "a.gcnodir"}, {SrcPath: "b.symbol_order"}}
+	got := CoverageZipsPhonyDeps(entries)
+	want := []string{"a.gcnodir", "b.symbol_order"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
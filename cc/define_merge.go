@@ -0,0 +1,69 @@
+package cc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mergedDefine is one -D flag after parsing, split into its macro name and value (value is ""
+// for a bare "-DFOO" with no "=").
+type mergedDefine struct {
+	source string // which flag source this came from, for the conflict error
+	name   string
+	value  string
+}
+
+// parseDefine splits a "-DNAME" or "-DNAME=value" flag into its macro name and value.
+func parseDefine(flag string) (name, value string, ok bool) {
+	if !strings.HasPrefix(flag, "-D") {
+		return "", "", false
+	}
+	body := flag[2:]
+	if eq := strings.IndexByte(body, '='); eq != -1 {
+		return body[:eq], body[eq+1:], true
+	}
+	return body, "", true
+}
+
+// MergeDuplicateDefines scans flagsBySource (a module's flags grouped by source, e.g. "cflags",
+// "cppflags", a dependency's exported flags) for -D defines that name the same macro. Defines
+// with the same name and the same value are merged into one; defines with the same name and
+// different values produce an error naming both conflicting sources, instead of silently letting
+// the compiler apply last-one-wins semantics.
+func MergeDuplicateDefines(flagsBySource map[string][]string) ([]string, error) {
+	var order []string
+	seen := make(map[string]mergedDefine)
+
+	for _, source := range sortedKeys(flagsBySource) {
+		for _, flag := range flagsBySource[source] {
+			name, value, ok := parseDefine(flag)
+			if !ok {
+				order = append(order, flag)
+				continue
+			}
+			if existing, ok := seen[name]; ok {
+				if existing.value != value {
+					return nil, fmt.Errorf("conflicting values for -D%s: %q (from %s) vs %q (from %s)",
+						name, existing.value, existing.source, value, source)
+				}
+				continue // identical define already included
+			}
+			seen[name] = mergedDefine{source: source, name: name, value: value}
+			order = append(order, flag)
+		}
+	}
+	return order, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j-1] > keys[j]; j-- {
+			keys[j-1], keys[j] = keys[j], keys[j-1]
+		}
+	}
+	return keys
+}
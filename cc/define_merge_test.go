@@ -0,0 +1,43 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDuplicateDefines_IdenticalValuesMerged(t *testing.T) {
+	got, err := MergeDuplicateDefines(map[string][]string{
+		"cflags":   {"-DFOO=1"},
+		"depflags": {"-DFOO=1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"-DFOO=1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMergeDuplicateDefines_ConflictingValues(t *testing.T) {
+	_, err := MergeDuplicateDefines(map[string][]string{
+		"cflags":   {"-DFOO=1"},
+		"depflags": {"-DFOO=2"},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for conflicting -DFOO values")
+	}
+}
+
+func TestMergeDuplicateDefines_DistinctNamesKept(t *testing.T) {
+	got, err := MergeDuplicateDefines(map[string][]string{
+		"cflags": {"-DFOO=1", "-DBAR"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"-DFOO=1", "-DBAR"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package cc
+
+// FlagExporterProperties holds the include dirs (and, per-target, overrides of them) that a
+// module exports to its reverse dependencies via FlagExporter.
+type FlagExporterProperties struct {
+	Export_include_dirs []string
+
+	Target struct {
+		Host struct {
+			// Export_include_dirs, when set, is appended to the top-level
+			// Export_include_dirs for the host variant only — for compatibility shims
+			// (e.g. headers standing in for missing bionic headers) that must not leak into
+			// the device variant's exported flags.
+			Export_include_dirs []string
+		}
+	}
+}
+
+// ExportedIncludeDirsForVariant returns the include dirs this module exports for the given
+// target, applying target.host.export_include_dirs on top of the top-level
+// Export_include_dirs when isHost is true, so host and device variants of the same dual-target
+// library can export different -I flags to their consumers.
+func ExportedIncludeDirsForVariant(props FlagExporterProperties, isHost bool) []string {
+	dirs := append([]string(nil), props.Export_include_dirs...)
+	if isHost {
+		dirs = append(dirs, props.Target.Host.Export_include_dirs...)
+	}
+	return dirs
+}
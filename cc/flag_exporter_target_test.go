@@ -0,0 +1,26 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExportedIncludeDirsForVariant_HostAddsCompatShim(t *testing.T) {
+	props := FlagExporterProperties{Export_include_dirs: []string{"include"}}
+	props.Target.Host.Export_include_dirs = []string{"include_host_shim"}
+
+	host := ExportedIncludeDirsForVariant(props, true)
+	device := ExportedIncludeDirsForVariant(props, false)
+
+	wantHost := []string{"include", "include_host_shim"}
+	wantDevice := []string{"include"}
+	if !reflect.DeepEqual(host, wantHost) {
+		t.Errorf("got host dirs %v, want %v", host, wantHost)
+	}
+	if !reflect.DeepEqual(device, wantDevice) {
+		t.Errorf("got device dirs %v, want %v", device, wantDevice)
+	}
+	if reflect.DeepEqual(host, device) {
+		t.Errorf("expected host and device exported include dirs to differ")
+	}
+}
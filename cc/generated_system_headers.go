@@ -0,0 +1,44 @@
+package cc
+
+import "android/soong/android"
+
+// GeneratedSystemHeadersProperties holds the `generated_system_headers` property: generated_headers
+// deps whose dirs should be routed through -isystem instead of plain -I, so warnings from those
+// headers can be suppressed.
+type GeneratedSystemHeadersProperties struct {
+	// Generated_system_headers names generated_headers deps to treat as system includes for this
+	// module itself.
+	Generated_system_headers []string
+	// Export_generated_system_headers re-exports the named generated_headers deps as system
+	// includes to dependents, instead of (or in addition to) plain export_generated_headers.
+	Export_generated_system_headers []string
+}
+
+// ResolveGeneratedSystemHeaderDirs returns the include dirs that should be added as -isystem for
+// this module's own compile, gathered from the named generated_headers deps' providers.
+func ResolveGeneratedSystemHeaderDirs(depNames []string) []string {
+	var dirs []string
+	for _, dep := range depNames {
+		if info, ok := android.GeneratedSourceInfoFor(dep); ok {
+			dirs = append(dirs, info.GeneratedHeaderDirs...)
+		}
+	}
+	return dirs
+}
+
+// ExportedGeneratedSystemHeaderDirs returns the SystemIncludeDirs a dependent consuming this
+// module's FlagExporterInfo should receive, for the re-exported generated_headers deps.
+func ExportedGeneratedSystemHeaderDirs(props GeneratedSystemHeadersProperties) []string {
+	return ResolveGeneratedSystemHeaderDirs(props.Export_generated_system_headers)
+}
+
+// OrderIncludeFlags places -isystem flags for generated system headers after the plain -I local
+// include flags, matching the ordering cc already uses for toolchain system includes.
+func OrderIncludeFlags(localIncludeFlags, systemIncludeFlags []string) []string {
+	result := make([]string, 0, len(localIncludeFlags)+len(systemIncludeFlags))
+	result = append(result, localIncludeFlags...)
+	for _, dir := range systemIncludeFlags {
+		result = append(result, "-isystem", dir)
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+
+	"android/soong/android"
+)
+
+func TestResolveGeneratedSystemHeaderDirs(t *testing.T) {
+	android.ResetGeneratedSourceInfoForTests()
+	defer android.ResetGeneratedSourceInfoForTests()
+
+	android.SetGeneratedSourceInfo("gen_hdrs", android.GeneratedSourceInfo{
+		GeneratedHeaderDirs: []string{"out/gen_hdrs/include"},
+	})
+
+	got := ResolveGeneratedSystemHeaderDirs([]string{"gen_hdrs"})
+	want := []string{"out/gen_hdrs/include"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestExportedGeneratedSystemHeaderDirs(t *testing.T) {
+	android.ResetGeneratedSourceInfoForTests()
+	defer android.ResetGeneratedSourceInfoForTests()
+
+	android.SetGeneratedSourceInfo("gen_hdrs", android.GeneratedSourceInfo{
+		GeneratedHeaderDirs: []string{"out/gen_hdrs/include"},
+	})
+
+	props := GeneratedSystemHeadersProperties{Export_generated_system_headers: []string{"gen_hdrs"}}
+	got := ExportedGeneratedSystemHeaderDirs(props)
+	if len(got) != 1 {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestOrderIncludeFlags_LocalBeforeSystem(t *testing.T) {
+	got := OrderIncludeFlags([]string{"-Ifoo"}, []string{"out/gen/include"})
+	want := []string{"-Ifoo", "-isystem", "out/gen/include"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
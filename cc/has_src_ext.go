@@ -0,0 +1,49 @@
+package cc
+
+import (
+	"path/filepath"
+
+	"android/soong/android"
+)
+
+// HasSrcExtInputs is what hasSrcExt needs in order to decide whether a module's sources (direct,
+// reused-object, or generated) include a given extension, for gating aidl/proto/sysprop header
+// export decisions in the compiler.
+type HasSrcExtInputs struct {
+	// Srcs are the module's own Properties.Srcs.
+	Srcs []string
+	// OriginalSrcs are the pre-object-reuse source list (set when a variant reuses another
+	// variant's static objects and consults the original module's sources instead of its own,
+	// now-emptied Srcs).
+	OriginalSrcs []string
+	// GeneratedSourceFiles are outputs from generated_sources deps, gathered via
+	// android.GeneratedSourceInfo.
+	GeneratedSourceFiles []string
+}
+
+// hasSrcExt reports whether any source under consideration - the module's own Srcs,
+// OriginalSrcs (after object reuse), or files contributed by generated_sources deps - has the
+// given extension. ext must include the leading dot, e.g. ".proto".
+func hasSrcExt(in HasSrcExtInputs, ext string) bool {
+	for _, srcs := range [][]string{in.Srcs, in.OriginalSrcs, in.GeneratedSourceFiles} {
+		for _, src := range srcs {
+			if filepath.Ext(src) == ext {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasSrcExtForModule builds HasSrcExtInputs from a module's own source lists plus whatever
+// generated_sources deps registered via android.SetGeneratedSourceInfo, and reports whether ext
+// is present among them.
+func HasSrcExtForModule(srcs, originalSrcs []string, generatedSourceDeps []string, ext string) bool {
+	var generated []string
+	for _, dep := range generatedSourceDeps {
+		if info, ok := android.GeneratedSourceInfoFor(dep); ok {
+			generated = append(generated, info.GeneratedSourceFiles...)
+		}
+	}
+	return hasSrcExt(HasSrcExtInputs{Srcs: srcs, OriginalSrcs: originalSrcs, GeneratedSourceFiles: generated}, ext)
+}
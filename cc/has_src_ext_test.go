@@ -0,0 +1,40 @@
+package cc
+
+import (
+	"testing"
+
+	"android/soong/android"
+)
+
+func TestHasSrcExt_DirectSrcs(t *testing.T) {
+	in := HasSrcExtInputs{Srcs: []string{"foo.cpp", "bar.proto"}}
+	if !hasSrcExt(in, ".proto") {
+		t.Errorf("expected .proto to be found in direct Srcs")
+	}
+	if hasSrcExt(in, ".aidl") {
+		t.Errorf("did not expect .aidl to be found")
+	}
+}
+
+func TestHasSrcExt_OriginalSrcsAfterReuse(t *testing.T) {
+	in := HasSrcExtInputs{OriginalSrcs: []string{"reused.proto"}}
+	if !hasSrcExt(in, ".proto") {
+		t.Errorf("expected .proto to be found in OriginalSrcs")
+	}
+}
+
+func TestHasSrcExtForModule_GeneratedSources(t *testing.T) {
+	android.ResetGeneratedSourceInfoForTests()
+	defer android.ResetGeneratedSourceInfoForTests()
+
+	android.SetGeneratedSourceInfo("gen_proto", android.GeneratedSourceInfo{
+		GeneratedSourceFiles: []string{"out/gen_proto/foo.proto"},
+	})
+
+	if !HasSrcExtForModule(nil, nil, []string{"gen_proto"}, ".proto") {
+		t.Errorf("expected .proto to be found via generated_sources dep")
+	}
+	if HasSrcExtForModule(nil, nil, []string{"gen_proto"}, ".aidl") {
+		t.Errorf("did not expect .aidl to be found")
+	}
+}
@@ -0,0 +1,63 @@
+package cc
+
+import "fmt"
+
+// HeaderApiUsage is one API symbol referenced from an exported header, as discovered by the
+// self-containedness harness reused for this check.
+type HeaderApiUsage struct {
+	Symbol        string
+	HeaderFile    string
+	IntroducedApi int
+	Guarded       bool // true if wrapped in __builtin_available or an equivalent API-level guard
+}
+
+// HeaderMinSdkCheckProperties opts a cc_library_headers module into verifying its exported
+// headers don't reference APIs newer than its consumers' minimum supported API level.
+type HeaderMinSdkCheckProperties struct {
+	Verify_min_sdk_headers *bool
+}
+
+func (p *HeaderMinSdkCheckProperties) enabled() bool {
+	return p.Verify_min_sdk_headers != nil && *p.Verify_min_sdk_headers
+}
+
+// HeaderApiViolation names one unguarded too-new API usage found in an exported header.
+type HeaderApiViolation struct {
+	HeaderFile     string
+	Symbol         string
+	IntroducedApi  int
+	ConsumerMinSdk int
+}
+
+func (v HeaderApiViolation) Error() string {
+	return fmt.Sprintf(
+		"%s: %q was introduced in API %d, newer than the consuming module's min_sdk_version %d, "+
+			"and isn't guarded by __builtin_available; guard the call or raise min_sdk_version",
+		v.HeaderFile, v.Symbol, v.IntroducedApi, v.ConsumerMinSdk)
+}
+
+// VerifyHeaderMinSdkUsage checks every discovered API usage against the oldest min_sdk_version
+// among the header library's consumers, returning one HeaderApiViolation per unguarded usage that
+// is newer than that floor. Guarded usages never violate regardless of API level, since they're
+// conditionally compiled/executed.
+func VerifyHeaderMinSdkUsage(props HeaderMinSdkCheckProperties, usages []HeaderApiUsage, consumersMinSdk int) []error {
+	if !props.enabled() {
+		return nil
+	}
+
+	var errs []error
+	for _, u := range usages {
+		if u.Guarded {
+			continue
+		}
+		if u.IntroducedApi > consumersMinSdk {
+			errs = append(errs, HeaderApiViolation{
+				HeaderFile:     u.HeaderFile,
+				Symbol:         u.Symbol,
+				IntroducedApi:  u.IntroducedApi,
+				ConsumerMinSdk: consumersMinSdk,
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,31 @@
+package cc
+
+import "testing"
+
+func TestVerifyHeaderMinSdkUsage_UnguardedNewApi(t *testing.T) {
+	props := HeaderMinSdkCheckProperties{Verify_min_sdk_headers: boolPtr(true)}
+	usages := []HeaderApiUsage{
+		{Symbol: "new_api", HeaderFile: "foo.h", IntroducedApi: 31},
+	}
+	errs := VerifyHeaderMinSdkUsage(props, usages, 29)
+	if len(errs) != 1 {
+		t.Fatalf("expected one violation, got %v", errs)
+	}
+}
+
+func TestVerifyHeaderMinSdkUsage_GuardedNewApiOk(t *testing.T) {
+	props := HeaderMinSdkCheckProperties{Verify_min_sdk_headers: boolPtr(true)}
+	usages := []HeaderApiUsage{
+		{Symbol: "new_api", HeaderFile: "foo.h", IntroducedApi: 31, Guarded: true},
+	}
+	if errs := VerifyHeaderMinSdkUsage(props, usages, 29); len(errs) != 0 {
+		t.Errorf("expected no violations for a guarded usage, got %v", errs)
+	}
+}
+
+func TestVerifyHeaderMinSdkUsage_DisabledByDefault(t *testing.T) {
+	usages := []HeaderApiUsage{{Symbol: "new_api", HeaderFile: "foo.h", IntroducedApi: 31}}
+	if errs := VerifyHeaderMinSdkUsage(HeaderMinSdkCheckProperties{}, usages, 29); len(errs) != 0 {
+		t.Errorf("expected no check without opting in, got %v", errs)
+	}
+}
@@ -0,0 +1,85 @@
+package cc
+
+import "sync"
+
+// UniqueHostSonameProperties holds the Android.bp properties that control whether a host
+// variant's installed file name gets "-host" appended to avoid colliding with a
+// system-installed library of the same name.
+type UniqueHostSonameProperties struct {
+	// Unique_host_soname appends "-host" to this module's host variant installed file name
+	// when a collision with a device module of the same stem is detected, or unconditionally
+	// when Unique_host_soname_force is also set.
+	Unique_host_soname *bool
+
+	// Unique_host_soname_force renames the host variant even when no colliding device module
+	// is detected, for host packaging pipelines that want the renamed name unconditionally.
+	Unique_host_soname_force *bool
+}
+
+func (p *UniqueHostSonameProperties) enabled() bool {
+	return p.Unique_host_soname != nil && *p.Unique_host_soname
+}
+
+func (p *UniqueHostSonameProperties) force() bool {
+	return p.Unique_host_soname_force != nil && *p.Unique_host_soname_force
+}
+
+// HostSonameRenameInfo is the provider data emitted for every host module whose installed name
+// was affected by Unique_host_soname, so host packaging scripts can look up the rename mapping
+// instead of re-deriving it.
+type HostSonameRenameInfo struct {
+	ModuleName      string
+	OriginalName    string
+	RenamedName     string
+	IsStaticArchive bool
+}
+
+// hostSonameRenameProviderTable is the type of hostSonameRenameProvider, the package-global table
+// that GenerateHostSonameRename populates.
+// It plays the role the real mutator/provider pipeline would: recording one entry per module
+// whose host soname was actually renamed.
+type hostSonameRenameProviderTable struct {
+	mu      sync.Mutex
+	entries map[string]HostSonameRenameInfo
+}
+
+var hostSonameRenameProvider = &hostSonameRenameProviderTable{entries: map[string]HostSonameRenameInfo{}}
+
+func (t *hostSonameRenameProviderTable) record(info HostSonameRenameInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries[info.ModuleName] = info
+}
+
+// HostSonameRenameFor looks up the rename recorded for moduleName, for use by host packaging
+// scripts and tests.
+func HostSonameRenameFor(moduleName string) (HostSonameRenameInfo, bool) {
+	hostSonameRenameProvider.mu.Lock()
+	defer hostSonameRenameProvider.mu.Unlock()
+	info, ok := hostSonameRenameProvider.entries[moduleName]
+	return info, ok
+}
+
+// GenerateHostSonameRename computes whether moduleName's host variant should be renamed by
+// appending "-host", and records the result in the provider table when it is. The rename only
+// happens when deviceModuleExists (a device module of the same stem was found) or the module
+// opted into Unique_host_soname_force; otherwise a host variant with no collision keeps its
+// name. isStaticArchive extends the same detection and renaming to host static archives, which
+// can equally collide with a system-installed library during host packaging.
+func GenerateHostSonameRename(moduleName string, isStaticArchive bool, deviceModuleExists bool, props UniqueHostSonameProperties) (renamedName string, renamed bool) {
+	if !props.enabled() {
+		return moduleName, false
+	}
+	if !deviceModuleExists && !props.force() {
+		return moduleName, false
+	}
+
+	renamedName = moduleName + "-host"
+	hostSonameRenameProvider.record(HostSonameRenameInfo{
+		ModuleName:      moduleName,
+		OriginalName:    moduleName,
+		RenamedName:     renamedName,
+		IsStaticArchive: isStaticArchive,
+	})
+	return renamedName, true
+}
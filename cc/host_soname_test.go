@@ -0,0 +1,64 @@
+package cc
+
+import "testing"
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestGenerateHostSonameRename_NoCollisionNoForce(t *testing.T) {
+	props := UniqueHostSonameProperties{Unique_host_soname: boolPtr(true)}
+	name, renamed := GenerateHostSonameRename("libfoo", false, false, props)
+	if renamed {
+		t.Errorf("expected no rename without a collision or force, got renamed name %q", name)
+	}
+	if name != "libfoo" {
+		t.Errorf("expected name to be unchanged, got %q", name)
+	}
+	if _, ok := HostSonameRenameFor("libfoo"); ok {
+		t.Errorf("did not expect a provider entry to be recorded")
+	}
+}
+
+func TestGenerateHostSonameRename_Collision(t *testing.T) {
+	props := UniqueHostSonameProperties{Unique_host_soname: boolPtr(true)}
+	name, renamed := GenerateHostSonameRename("libbar", false, true, props)
+	if !renamed || name != "libbar-host" {
+		t.Errorf("expected rename to libbar-host, got %q (renamed=%v)", name, renamed)
+	}
+	info, ok := HostSonameRenameFor("libbar")
+	if !ok {
+		t.Fatalf("expected a provider entry to be recorded")
+	}
+	if info.RenamedName != "libbar-host" || info.IsStaticArchive {
+		t.Errorf("unexpected provider entry: %+v", info)
+	}
+}
+
+func TestGenerateHostSonameRename_ForceWithoutCollision(t *testing.T) {
+	props := UniqueHostSonameProperties{
+		Unique_host_soname:       boolPtr(true),
+		Unique_host_soname_force: boolPtr(true),
+	}
+	name, renamed := GenerateHostSonameRename("libbaz", false, false, props)
+	if !renamed || name != "libbaz-host" {
+		t.Errorf("expected forced rename to libbaz-host, got %q (renamed=%v)", name, renamed)
+	}
+}
+
+func TestGenerateHostSonameRename_StaticArchiveCollision(t *testing.T) {
+	props := UniqueHostSonameProperties{Unique_host_soname: boolPtr(true)}
+	name, renamed := GenerateHostSonameRename("libqux", true, true, props)
+	if !renamed || name != "libqux-host" {
+		t.Errorf("expected static archive rename to libqux-host, got %q (renamed=%v)", name, renamed)
+	}
+	info, ok := HostSonameRenameFor("libqux")
+	if !ok || !info.IsStaticArchive {
+		t.Errorf("expected static archive provider entry, got %+v (ok=%v)", info, ok)
+	}
+}
+
+func TestGenerateHostSonameRename_Disabled(t *testing.T) {
+	name, renamed := GenerateHostSonameRename("libnorename", false, true, UniqueHostSonameProperties{})
+	if renamed || name != "libnorename" {
+		t.Errorf("expected no-op when Unique_host_soname unset, got %q (renamed=%v)", name, renamed)
+	}
+}
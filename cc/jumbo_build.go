@@ -0,0 +1,74 @@
+package cc
+
+import "fmt"
+
+// JumboProperties holds the `jumbo` opt-in amalgamation-build property.
+type JumboProperties struct {
+	Jumbo struct {
+		Enabled      *bool
+		Chunk_size   *int
+		Exclude_srcs []string
+	}
+}
+
+// JumboEnabled reports whether the module opted into jumbo compilation.
+func JumboEnabled(props JumboProperties) bool {
+	return props.Jumbo.Enabled != nil && *props.Jumbo.Enabled
+}
+
+func jumboChunkSize(props JumboProperties) int {
+	if props.Jumbo.Chunk_size != nil && *props.Jumbo.Chunk_size > 0 {
+		return *props.Jumbo.Chunk_size
+	}
+	return 50
+}
+
+// JumboChunk is one amalgamation unit: the generated .cpp that #includes Sources.
+type JumboChunk struct {
+	AmalgamationFile string
+	Sources          []string
+}
+
+// PartitionJumboSources splits srcs into jumbo amalgamation chunks and a pass-through list of
+// sources that must still be compiled individually, either because they're excluded via
+// jumbo.exclude_srcs or the module didn't opt into jumbo mode at all.
+func PartitionJumboSources(props JumboProperties, srcs []string, amalgamationDir string) (chunks []JumboChunk, individualSrcs []string) {
+	if !JumboEnabled(props) {
+		return nil, srcs
+	}
+
+	excluded := map[string]bool{}
+	for _, e := range props.Jumbo.Exclude_srcs {
+		excluded[e] = true
+	}
+
+	var jumboSrcs []string
+	for _, src := range srcs {
+		if excluded[src] {
+			individualSrcs = append(individualSrcs, src)
+		} else {
+			jumboSrcs = append(jumboSrcs, src)
+		}
+	}
+
+	chunkSize := jumboChunkSize(props)
+	for i := 0; i < len(jumboSrcs); i += chunkSize {
+		end := i + chunkSize
+		if end > len(jumboSrcs) {
+			end = len(jumboSrcs)
+		}
+		chunk := jumboSrcs[i:end]
+		chunks = append(chunks, JumboChunk{
+			AmalgamationFile: fmt.Sprintf("%s/jumbo_%d.cpp", amalgamationDir, len(chunks)),
+			Sources:          chunk,
+		})
+	}
+	return chunks, individualSrcs
+}
+
+// NonAmalgamatedSourcesForAuxiliaryTools returns the original, per-file source list that
+// coverage, sabi dump, and tidy must operate on instead of the jumbo amalgamations, since those
+// tools need per-translation-unit fidelity that an amalgamation would hide.
+func NonAmalgamatedSourcesForAuxiliaryTools(originalSrcs []string) []string {
+	return originalSrcs
+}
@@ -0,0 +1,44 @@
+package cc
+
+import "testing"
+
+func jumboBoolPtr(b bool) *bool { return &b }
+func jumboIntPtr(i int) *int    { return &i }
+
+func TestPartitionJumboSources_Disabled(t *testing.T) {
+	chunks, individual := PartitionJumboSources(JumboProperties{}, []string{"a.cpp", "b.cpp"}, "out/gen")
+	if len(chunks) != 0 {
+		t.Errorf("expected no chunks when disabled")
+	}
+	if len(individual) != 2 {
+		t.Errorf("expected all sources to pass through individually")
+	}
+}
+
+func TestPartitionJumboSources_ChunksAndExcludes(t *testing.T) {
+	props := JumboProperties{}
+	props.Jumbo.Enabled = jumboBoolPtr(true)
+	props.Jumbo.Chunk_size = jumboIntPtr(2)
+	props.Jumbo.Exclude_srcs = []string{"weird.cpp"}
+
+	srcs := []string{"a.cpp", "b.cpp", "c.cpp", "weird.cpp"}
+	chunks, individual := PartitionJumboSources(props, srcs, "out/gen")
+
+	if len(individual) != 1 || individual[0] != "weird.cpp" {
+		t.Errorf("got individual %v, want [weird.cpp]", individual)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	if len(chunks[0].Sources) != 2 || len(chunks[1].Sources) != 1 {
+		t.Errorf("got chunk sizes %d, %d; want 2, 1", len(chunks[0].Sources), len(chunks[1].Sources))
+	}
+}
+
+func TestNonAmalgamatedSourcesForAuxiliaryTools(t *testing.T) {
+	srcs := []string{"a.cpp", "b.cpp"}
+	got := NonAmalgamatedSourcesForAuxiliaryTools(srcs)
+	if len(got) != 2 {
+		t.Errorf("expected original sources preserved for tidy/sabi/coverage")
+	}
+}
@@ -0,0 +1,70 @@
+package cc
+
+import "fmt"
+
+// BazelCcLibraryResult is what a BazelContext query for a cc_library target returns: the paths
+// mixed builds need to populate the providers Soong's own compile/link actions would have set.
+type BazelCcLibraryResult struct {
+	StaticArchive    string
+	SharedLibrary    string
+	TocFile          string
+	ExportedIncludes []string
+}
+
+// ConfigKey identifies one arch/os/image-variant/sdk-variant configuration a Bazel query result
+// is keyed by. SdkVersion distinguishes SplitPerApiLevel variants (e.g. crt objects built once
+// per API level), which would otherwise all collapse onto the same ArchType-only key and
+// incorrectly share one Bazel output.
+type ConfigKey struct {
+	Arch         string
+	Os           string
+	ImageVariant string
+	SdkVersion   string
+}
+
+// BazelLibraryContext is the subset of BazelContext libraryBazelHandler needs: a query by label
+// and configuration returning the Bazel-built artifacts for a cc_library target.
+type BazelLibraryContext interface {
+	GetLibraryInfo(label string, key ConfigKey) (BazelCcLibraryResult, bool)
+}
+
+// StaticLibraryInfo and SharedLibraryInfo mirror the provider structs cc's own compile/link
+// actions populate, kept minimal to the fields libraryBazelHandler sets from a Bazel result.
+type StaticLibraryInfo struct {
+	StaticArchive string
+}
+
+type SharedLibraryInfo struct {
+	SharedLibrary string
+	TocFile       string
+}
+
+type FlagExporterInfo struct {
+	IncludeDirs []string
+}
+
+// LibraryBazelHandlerResult bundles every provider libraryBazelHandler populates for a
+// Bazel-delegated cc_library module, so callers get a single value instead of three separate
+// provider-set calls.
+type LibraryBazelHandlerResult struct {
+	Static       StaticLibraryInfo
+	Shared       SharedLibraryInfo
+	FlagExporter FlagExporterInfo
+}
+
+// ResolveLibraryFromBazel queries ctx for label under key and converts the result into the
+// provider values a Bazel-delegated cc_library module should expose, skipping Soong's own
+// compile/link actions. It errors when the query misses, naming the configuration queried so a
+// missing key is easy to diagnose instead of a dependent linking against nothing.
+func ResolveLibraryFromBazel(ctx BazelLibraryContext, label string, key ConfigKey) (*LibraryBazelHandlerResult, error) {
+	result, ok := ctx.GetLibraryInfo(label, key)
+	if !ok {
+		return nil, fmt.Errorf("bazel query miss for %q at %+v", label, key)
+	}
+
+	return &LibraryBazelHandlerResult{
+		Static:       StaticLibraryInfo{StaticArchive: result.StaticArchive},
+		Shared:       SharedLibraryInfo{SharedLibrary: result.SharedLibrary, TocFile: result.TocFile},
+		FlagExporter: FlagExporterInfo{IncludeDirs: result.ExportedIncludes},
+	}, nil
+}
@@ -0,0 +1,45 @@
+package cc
+
+import "testing"
+
+type fakeBazelLibraryContext struct {
+	results map[string]BazelCcLibraryResult
+}
+
+func (f *fakeBazelLibraryContext) GetLibraryInfo(label string, key ConfigKey) (BazelCcLibraryResult, bool) {
+	r, ok := f.results[label+"|"+key.Arch+"|"+key.Os+"|"+key.ImageVariant]
+	return r, ok
+}
+
+func TestResolveLibraryFromBazel_PopulatesProviders(t *testing.T) {
+	ctx := &fakeBazelLibraryContext{results: map[string]BazelCcLibraryResult{
+		"//foo:libfoo|arm64|android|core": {
+			StaticArchive:    "libfoo.a",
+			SharedLibrary:    "libfoo.so",
+			TocFile:          "libfoo.so.toc",
+			ExportedIncludes: []string{"foo/include"},
+		},
+	}}
+	key := ConfigKey{Arch: "arm64", Os: "android", ImageVariant: "core"}
+
+	got, err := ResolveLibraryFromBazel(ctx, "//foo:libfoo", key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Shared.SharedLibrary != "libfoo.so" {
+		t.Errorf("got %q", got.Shared.SharedLibrary)
+	}
+	if got.Static.StaticArchive != "libfoo.a" {
+		t.Errorf("got %q", got.Static.StaticArchive)
+	}
+	if len(got.FlagExporter.IncludeDirs) != 1 {
+		t.Errorf("got %v", got.FlagExporter.IncludeDirs)
+	}
+}
+
+func TestResolveLibraryFromBazel_QueryMissErrors(t *testing.T) {
+	ctx := &fakeBazelLibraryContext{results: map[string]BazelCcLibraryResult{}}
+	if _, err := ResolveLibraryFromBazel(ctx, "//foo:libfoo", ConfigKey{Arch: "arm64"}); err == nil {
+		t.Errorf("expected an error for a query miss")
+	}
+}
@@ -0,0 +1,56 @@
+package cc
+
+import "fmt"
+
+// LinkageVariantAvailability records whether a cc module's static and shared variants are
+// individually enabled, as set by its static{}/shared{} property blocks.
+type LinkageVariantAvailability struct {
+	StaticEnabled *bool
+	SharedEnabled *bool
+}
+
+func enabledOrDefault(b *bool) bool {
+	return b == nil || *b
+}
+
+// LinkageDisabledError explains that a dependency exists but has disabled the specific linkage a
+// consumer requested, instead of blueprint's generic "missing variant" message.
+type LinkageDisabledError struct {
+	DepName          string
+	RequestedLinkage string // "static" or "shared"
+	DisabledProperty string // e.g. "static.enabled"
+	Alternative      string
+}
+
+func (e *LinkageDisabledError) Error() string {
+	return fmt.Sprintf(
+		"module %q requested as a %s library, but %q sets %q, disabling that linkage; %s",
+		e.DepName, e.RequestedLinkage, e.DepName, e.DisabledProperty, e.Alternative)
+}
+
+// ValidateLinkageAvailable checks whether dep provides the linkage the consumer requested
+// ("static" or "shared"), returning a *LinkageDisabledError naming the disabling property and
+// suggesting an alternative when it doesn't.
+func ValidateLinkageAvailable(depName string, avail LinkageVariantAvailability, requestedLinkage string) error {
+	switch requestedLinkage {
+	case "static":
+		if !enabledOrDefault(avail.StaticEnabled) {
+			return &LinkageDisabledError{
+				DepName:          depName,
+				RequestedLinkage: "static",
+				DisabledProperty: "static.enabled",
+				Alternative:      "use shared_libs instead, or set static.enabled: true on " + depName,
+			}
+		}
+	case "shared":
+		if !enabledOrDefault(avail.SharedEnabled) {
+			return &LinkageDisabledError{
+				DepName:          depName,
+				RequestedLinkage: "shared",
+				DisabledProperty: "shared.enabled",
+				Alternative:      "use static_libs instead, or set shared.enabled: true on " + depName,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package cc
+
+import "testing"
+
+func TestValidateLinkageAvailable_StaticRequestedButDisabled(t *testing.T) {
+	avail := LinkageVariantAvailability{StaticEnabled: boolPtr(false)}
+	err := ValidateLinkageAvailable("libfoo", avail, "static")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	linkErr, ok := err.(*LinkageDisabledError)
+	if !ok {
+		t.Fatalf("expected *LinkageDisabledError, got %T", err)
+	}
+	if linkErr.DisabledProperty != "static.enabled" {
+		t.Errorf("got %q", linkErr.DisabledProperty)
+	}
+}
+
+func TestValidateLinkageAvailable_SharedRequestedButDisabled(t *testing.T) {
+	avail := LinkageVariantAvailability{SharedEnabled: boolPtr(false)}
+	err := ValidateLinkageAvailable("libfoo", avail, "shared")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	linkErr := err.(*LinkageDisabledError)
+	if linkErr.DisabledProperty != "shared.enabled" {
+		t.Errorf("got %q", linkErr.DisabledProperty)
+	}
+}
+
+func TestValidateLinkageAvailable_EnabledByDefault(t *testing.T) {
+	if err := ValidateLinkageAvailable("libfoo", LinkageVariantAvailability{}, "static"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateLinkageAvailable("libfoo", LinkageVariantAvailability{}, "shared"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
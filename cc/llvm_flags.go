@@ -0,0 +1,45 @@
+package cc
+
+import "fmt"
+
+// LlvmFlagsProperties holds a module's -mllvm backend flag passthrough, validated against a
+// config-provided allow-list so arbitrary backend flags can't leak into a module's cflags (and,
+// from there, into its exported flags via copy-paste).
+type LlvmFlagsProperties struct {
+	// Llvm_flags lists -mllvm flags to pass to the compiler (and LTO linker) for this module
+	// only. Every entry must appear in the toolchain's llvm_flags allow-list.
+	Llvm_flags []string
+}
+
+// ValidateLlvmFlags checks every entry of flags against allowList, returning an error naming the
+// first disallowed flag.
+func ValidateLlvmFlags(flags []string, allowList []string) error {
+	allowed := make(map[string]bool, len(allowList))
+	for _, f := range allowList {
+		allowed[f] = true
+	}
+	for _, f := range flags {
+		if !allowed[f] {
+			return fmt.Errorf("llvm_flags: %q is not in the allowed -mllvm flag list", f)
+		}
+	}
+	return nil
+}
+
+// CompileFlagsForLlvmFlags renders flags (already validated) as -mllvm compiler arguments, one
+// pair per flag, for use on the compile command and, when the module uses LTO, the link command.
+// These flags are never exported: FlagExporter callers must not include them.
+func CompileFlagsForLlvmFlags(flags []string) []string {
+	args := make([]string, 0, 2*len(flags))
+	for _, f := range flags {
+		args = append(args, "-mllvm", f)
+	}
+	return args
+}
+
+// BuildStatsLlvmFlags returns the llvm_flags value recorded in the module's build stats, which is
+// simply the validated flag list itself — kept as a separate entry point so build-stats
+// collection doesn't need to know how the compile command renders them.
+func BuildStatsLlvmFlags(flags []string) []string {
+	return append([]string(nil), flags...)
+}
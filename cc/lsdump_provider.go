@@ -0,0 +1,75 @@
+package cc
+
+import "sort"
+
+// LsdumpClass is the ABI class an lsdump belongs to, replacing the "CLASS:path" string encoding
+// addLsdumpPath used to pack into package-global state.
+type LsdumpClass string
+
+const (
+	LsdumpClassNdk      LsdumpClass = "NDK"
+	LsdumpClassPlatform LsdumpClass = "PLATFORM"
+	LsdumpClassVendor   LsdumpClass = "VENDOR"
+	LsdumpClassProduct  LsdumpClass = "PRODUCT"
+)
+
+// LsdumpInfo is the typed provider each library sets instead of calling addLsdumpPath.
+type LsdumpInfo struct {
+	ModuleName string
+	Class      LsdumpClass
+	LsdumpPath string
+	Arch       string
+}
+
+// lsdumpRegistry gathers every library's LsdumpInfo for the aggregating singleton to read back,
+// standing in for a real blueprint provider collection in this simulated pipeline.
+type lsdumpRegistry struct {
+	infos []LsdumpInfo
+}
+
+var globalLsdumpRegistry = &lsdumpRegistry{}
+
+// SetLsdumpInfo records a library's lsdump provider.
+func SetLsdumpInfo(info LsdumpInfo) {
+	globalLsdumpRegistry.infos = append(globalLsdumpRegistry.infos, info)
+}
+
+// ResetLsdumpRegistryForTests clears the registry. It exists only for test isolation.
+func ResetLsdumpRegistryForTests() {
+	globalLsdumpRegistry.infos = nil
+}
+
+// LsdumpOutputFile is one line of the aggregated output file, matching the legacy
+// "CLASS:path" text format so external tooling parsing it is unaffected by the provider
+// migration.
+type LsdumpOutputFile struct {
+	Class LsdumpClass
+	Path  string
+}
+
+// AggregateLsdumpOutputs builds the same output files the old addLsdumpPath-based singleton
+// produced: the entries sorted by class then module name then arch, so the aggregation is
+// deterministic regardless of library registration order.
+func AggregateLsdumpOutputs() []LsdumpOutputFile {
+	sorted := append([]LsdumpInfo(nil), globalLsdumpRegistry.infos...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Class != sorted[j].Class {
+			return sorted[i].Class < sorted[j].Class
+		}
+		if sorted[i].ModuleName != sorted[j].ModuleName {
+			return sorted[i].ModuleName < sorted[j].ModuleName
+		}
+		return sorted[i].Arch < sorted[j].Arch
+	})
+
+	outputs := make([]LsdumpOutputFile, len(sorted))
+	for i, info := range sorted {
+		outputs[i] = LsdumpOutputFile{Class: info.Class, Path: info.LsdumpPath}
+	}
+	return outputs
+}
+
+// FormatLsdumpLine renders one output line in the legacy "CLASS:path" text format.
+func FormatLsdumpLine(f LsdumpOutputFile) string {
+	return string(f.Class) + ":" + f.Path
+}
@@ -0,0 +1,30 @@
+package cc
+
+import "testing"
+
+func TestAggregateLsdumpOutputs_DeterministicOrder(t *testing.T) {
+	ResetLsdumpRegistryForTests()
+	defer ResetLsdumpRegistryForTests()
+
+	SetLsdumpInfo(LsdumpInfo{ModuleName: "libb", Class: LsdumpClassNdk, LsdumpPath: "libb.lsdump", Arch: "arm64"})
+	SetLsdumpInfo(LsdumpInfo{ModuleName: "liba", Class: LsdumpClassNdk, LsdumpPath: "liba.lsdump", Arch: "arm64"})
+	SetLsdumpInfo(LsdumpInfo{ModuleName: "libc", Class: LsdumpClassVendor, LsdumpPath: "libc.lsdump", Arch: "arm64"})
+
+	outputs := AggregateLsdumpOutputs()
+	if len(outputs) != 3 {
+		t.Fatalf("got %d outputs, want 3", len(outputs))
+	}
+	if outputs[0].Path != "liba.lsdump" || outputs[1].Path != "libb.lsdump" {
+		t.Errorf("got %+v, want liba then libb within NDK class", outputs[:2])
+	}
+	if outputs[2].Class != LsdumpClassVendor {
+		t.Errorf("got %+v, want VENDOR last", outputs[2])
+	}
+}
+
+func TestFormatLsdumpLine(t *testing.T) {
+	got := FormatLsdumpLine(LsdumpOutputFile{Class: LsdumpClassNdk, Path: "libfoo.lsdump"})
+	if got != "NDK:libfoo.lsdump" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,88 @@
+package cc
+
+import "fmt"
+
+// MemtagProperties holds the `sanitize: { memtag_heap, memtag_stack, diag: { memtag_heap } }`
+// property group.
+type MemtagProperties struct {
+	Memtag_heap  *bool
+	Memtag_stack *bool
+	Diag         struct {
+		Memtag_heap *bool
+	}
+}
+
+func memtagHeapEnabled(props MemtagProperties) bool {
+	return props.Memtag_heap != nil && *props.Memtag_heap
+}
+
+func memtagStackEnabled(props MemtagProperties) bool {
+	return props.Memtag_stack != nil && *props.Memtag_stack
+}
+
+func memtagDiagEnabled(props MemtagProperties) bool {
+	return props.Diag.Memtag_heap != nil && *props.Diag.Memtag_heap
+}
+
+// MemtagMode is whether tag-check faults abort immediately (sync) or are merely recorded for
+// later reporting (async).
+type MemtagMode string
+
+const (
+	MemtagModeSync  MemtagMode = "sync"
+	MemtagModeAsync MemtagMode = "async"
+)
+
+// ResolveMemtagMode returns sync when diag.memtag_heap is set (precise, for bug discovery) and
+// async otherwise (low-overhead, for production).
+func ResolveMemtagMode(props MemtagProperties) MemtagMode {
+	if memtagDiagEnabled(props) {
+		return MemtagModeSync
+	}
+	return MemtagModeAsync
+}
+
+// MemtagCompileAndLinkFlags returns the -fsanitize=memtag* flags for arm64 device variants, or
+// nil for arches other than arm64 and for host variants, which must silently ignore memtag
+// rather than error.
+func MemtagCompileAndLinkFlags(props MemtagProperties, arch string, isHost bool) []string {
+	if isHost || arch != "arm64" {
+		return nil
+	}
+
+	var flags []string
+	if memtagHeapEnabled(props) {
+		flags = append(flags, "-fsanitize=memtag-heap")
+	}
+	if memtagStackEnabled(props) {
+		flags = append(flags, "-fsanitize=memtag-stack")
+	}
+	if len(flags) == 0 {
+		return nil
+	}
+
+	mode := ResolveMemtagMode(props)
+	flags = append(flags, fmt.Sprintf("-fsanitize-memtag-mode=%s", mode))
+	return flags
+}
+
+// MemtagElfNoteSection returns the ELF note section content the loader expects to see on a
+// memtag-enabled binary, encoding the resolved mode.
+func MemtagElfNoteSection(props MemtagProperties) string {
+	return fmt.Sprintf("NT_MEMTAG_%s", string(ResolveMemtagMode(props)))
+}
+
+// memtagInSanitizerDir reports whether a memtag-enabled variant should install under the
+// sanitizer install dir. It's memtag's contribution to the aggregated cc.InSanitizerDir(
+// SanitizeProperties) decision, the single place a module checks "does any sanitizer mode need
+// the sanitizer install dir" rather than asking each mode individually.
+func memtagInSanitizerDir(props MemtagProperties) bool {
+	return memtagHeapEnabled(props) || memtagStackEnabled(props)
+}
+
+// MemtagNoteCarriesIntoApexPayload reports whether a memtag-enabled binary's ELF note must be
+// preserved when it's packaged into an apex payload, which is always true: the note is how the
+// loader decides to enable tag checking at load time, regardless of how the binary was shipped.
+func MemtagNoteCarriesIntoApexPayload(props MemtagProperties) bool {
+	return memtagInSanitizerDir(props)
+}
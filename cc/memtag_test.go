@@ -0,0 +1,52 @@
+package cc
+
+import "testing"
+
+func boolPtrMemtag(b bool) *bool { return &b }
+
+func TestMemtagCompileAndLinkFlags_AsyncByDefault(t *testing.T) {
+	props := MemtagProperties{Memtag_heap: boolPtrMemtag(true)}
+	flags := MemtagCompileAndLinkFlags(props, "arm64", false)
+	if len(flags) != 2 || flags[0] != "-fsanitize=memtag-heap" || flags[1] != "-fsanitize-memtag-mode=async" {
+		t.Errorf("got %v", flags)
+	}
+}
+
+func TestMemtagCompileAndLinkFlags_SyncWhenDiagEnabled(t *testing.T) {
+	props := MemtagProperties{Memtag_heap: boolPtrMemtag(true)}
+	props.Diag.Memtag_heap = boolPtrMemtag(true)
+	flags := MemtagCompileAndLinkFlags(props, "arm64", false)
+	if flags[len(flags)-1] != "-fsanitize-memtag-mode=sync" {
+		t.Errorf("got %v", flags)
+	}
+}
+
+func TestMemtagCompileAndLinkFlags_NonArm64Ignored(t *testing.T) {
+	props := MemtagProperties{Memtag_heap: boolPtrMemtag(true)}
+	if flags := MemtagCompileAndLinkFlags(props, "arm", false); flags != nil {
+		t.Errorf("expected nil on non-arm64, got %v", flags)
+	}
+}
+
+func TestMemtagCompileAndLinkFlags_HostIgnored(t *testing.T) {
+	props := MemtagProperties{Memtag_heap: boolPtrMemtag(true)}
+	if flags := MemtagCompileAndLinkFlags(props, "arm64", true); flags != nil {
+		t.Errorf("expected nil on host, got %v", flags)
+	}
+}
+
+func TestInSanitizerDir(t *testing.T) {
+	if !InSanitizerDir(SanitizeProperties{Memtag: MemtagProperties{Memtag_stack: boolPtrMemtag(true)}}) {
+		t.Errorf("expected memtag_stack to install under the sanitizer dir")
+	}
+	if InSanitizerDir(SanitizeProperties{}) {
+		t.Errorf("expected no sanitizer dir without memtag enabled")
+	}
+}
+
+func TestMemtagElfNoteSection(t *testing.T) {
+	props := MemtagProperties{Memtag_heap: boolPtrMemtag(true)}
+	if got := MemtagElfNoteSection(props); got != "NT_MEMTAG_async" {
+		t.Errorf("got %q", got)
+	}
+}
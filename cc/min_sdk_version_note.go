@@ -0,0 +1,56 @@
+package cc
+
+import "fmt"
+
+// MinSdkVersionNoteSectionName is the ELF section name used to stamp a library's effective
+// min_sdk_version so sdk-constrained processes can verify compatibility at load time.
+const MinSdkVersionNoteSectionName = ".note.android.minsdkversion"
+
+// MinSdkVersionNoteEnabled reports whether linkShared should add the min_sdk_version ELF note
+// for this link, based on the global config opt-in and whether the module is an apex member
+// (apex members get the note by default).
+func MinSdkVersionNoteEnabled(configEnabled bool, isApexMember bool) bool {
+	return configEnabled || isApexMember
+}
+
+// AddSectionRule builds the objcopy invocation that stamps minSdkVersion into
+// MinSdkVersionNoteSectionName of in, writing the result to out. It must run before strip and
+// TOC generation: objcopy --add-section appends a new section, and both strip and the TOC
+// generator need to observe the final section layout, not add the note after the fact where a
+// stripped binary could lose it.
+func AddSectionRule(objcopy, in, out, minSdkVersion string) string {
+	noteFile := out + ".minsdkversion.note"
+	return fmt.Sprintf("echo -n %s > %s && %s --add-section %s=%s %s %s",
+		shellQuoteNote(minSdkVersion), noteFile, objcopy, MinSdkVersionNoteSectionName, noteFile, in, out)
+}
+
+func shellQuoteNote(s string) string {
+	return "'" + s + "'"
+}
+
+// MinSdkVersionNoteOrder describes where in a link pipeline the add-section step must fall
+// relative to strip and TOC generation, so callers assembling linkShared's rule list can assert
+// on it.
+type MinSdkVersionNoteOrder int
+
+const (
+	OrderAddSection MinSdkVersionNoteOrder = iota
+	OrderStrip
+	OrderTOC
+)
+
+// LinkPipelineOrder returns the ordered steps linkShared runs when the min_sdk_version note is
+// enabled: add-section always precedes strip and TOC generation.
+func LinkPipelineOrder(noteEnabled bool) []MinSdkVersionNoteOrder {
+	if !noteEnabled {
+		return []MinSdkVersionNoteOrder{OrderStrip, OrderTOC}
+	}
+	return []MinSdkVersionNoteOrder{OrderAddSection, OrderStrip, OrderTOC}
+}
+
+// TOCIgnoresNoteSection reports whether a TOC generator that excludes non-dynamic-symbol
+// sections ignores sectionName: true for MinSdkVersionNoteSectionName, since the note section
+// carries no dynamic symbols, and false for any other section.
+func TOCIgnoresNoteSection(sectionName string) bool {
+	return sectionName == MinSdkVersionNoteSectionName
+}
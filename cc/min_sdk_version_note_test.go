@@ -0,0 +1,52 @@
+package cc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMinSdkVersionNoteEnabled(t *testing.T) {
+	if MinSdkVersionNoteEnabled(false, false) {
+		t.Errorf("expected disabled when neither config nor apex membership opts in")
+	}
+	if !MinSdkVersionNoteEnabled(true, false) {
+		t.Errorf("expected enabled via config opt-in")
+	}
+	if !MinSdkVersionNoteEnabled(false, true) {
+		t.Errorf("expected enabled by default for apex members")
+	}
+}
+
+func TestAddSectionRule(t *testing.T) {
+	rule := AddSectionRule("objcopy", "in.so", "out.so", "30")
+	if !strings.Contains(rule, "--add-section "+MinSdkVersionNoteSectionName+"=") {
+		t.Errorf("expected rule to add-section %s, got: %s", MinSdkVersionNoteSectionName, rule)
+	}
+	if !strings.Contains(rule, "in.so") || !strings.Contains(rule, "out.so") {
+		t.Errorf("expected rule to reference both in and out files, got: %s", rule)
+	}
+}
+
+func TestLinkPipelineOrder(t *testing.T) {
+	got := LinkPipelineOrder(true)
+	want := []MinSdkVersionNoteOrder{OrderAddSection, OrderStrip, OrderTOC}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got = LinkPipelineOrder(false)
+	want = []MinSdkVersionNoteOrder{OrderStrip, OrderTOC}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestTOCIgnoresNoteSection(t *testing.T) {
+	if !TOCIgnoresNoteSection(MinSdkVersionNoteSectionName) {
+		t.Errorf("expected the TOC to ignore the min_sdk_version note section")
+	}
+	if TOCIgnoresNoteSection(".dynsym") {
+		t.Errorf("expected the TOC to not ignore .dynsym")
+	}
+}
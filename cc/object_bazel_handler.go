@@ -0,0 +1,21 @@
+package cc
+
+import "fmt"
+
+// BazelObjectContext is the query interface objectBazelHandler uses, keyed by the full ConfigKey
+// (not just ArchType) so per-image and SplitPerApiLevel variants each get their own result.
+type BazelObjectContext interface {
+	GetOutputFiles(label string, key ConfigKey) ([]string, bool)
+}
+
+// ResolveObjectOutputFilesFromBazel queries ctx for label under key and returns the output files
+// for a Bazel-delegated cc_object variant. It errors naming the full configuration when the query
+// misses, since a silent miss would previously have had every variant share one wrong result.
+func ResolveObjectOutputFilesFromBazel(ctx BazelObjectContext, label string, key ConfigKey) ([]string, error) {
+	files, ok := ctx.GetOutputFiles(label, key)
+	if !ok {
+		return nil, fmt.Errorf("bazel query miss for %q at variation {arch:%s os:%s image:%s sdk:%s}",
+			label, key.Arch, key.Os, key.ImageVariant, key.SdkVersion)
+	}
+	return files, nil
+}
@@ -0,0 +1,41 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fakeBazelObjectContext struct {
+	results map[ConfigKey][]string
+}
+
+func (f *fakeBazelObjectContext) GetOutputFiles(label string, key ConfigKey) ([]string, bool) {
+	files, ok := f.results[key]
+	return files, ok
+}
+
+func TestResolveObjectOutputFilesFromBazel_DistinctPerSdkVersion(t *testing.T) {
+	ctx := &fakeBazelObjectContext{results: map[ConfigKey][]string{
+		{Arch: "arm64", Os: "android", SdkVersion: "29"}: {"crtbegin_29.o"},
+		{Arch: "arm64", Os: "android", SdkVersion: "30"}: {"crtbegin_30.o"},
+	}}
+
+	got29, err := ResolveObjectOutputFilesFromBazel(ctx, "//bionic:crtbegin", ConfigKey{Arch: "arm64", Os: "android", SdkVersion: "29"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got30, err := ResolveObjectOutputFilesFromBazel(ctx, "//bionic:crtbegin", ConfigKey{Arch: "arm64", Os: "android", SdkVersion: "30"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reflect.DeepEqual(got29, got30) {
+		t.Errorf("expected distinct outputs per sdk version, got %v for both", got29)
+	}
+}
+
+func TestResolveObjectOutputFilesFromBazel_UnknownKeyErrors(t *testing.T) {
+	ctx := &fakeBazelObjectContext{results: map[ConfigKey][]string{}}
+	if _, err := ResolveObjectOutputFilesFromBazel(ctx, "//bionic:crtbegin", ConfigKey{Arch: "arm", ImageVariant: "vendor"}); err == nil {
+		t.Errorf("expected an error naming the missing variant")
+	}
+}
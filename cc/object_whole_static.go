@@ -0,0 +1,40 @@
+package cc
+
+import "fmt"
+
+// ObjectWholeStaticLibsProperties lets a cc_object fold a static archive's members into its
+// partial link, for crt-like objects that need to include a small archive without listing each
+// member as a separate cc_object dependency.
+type ObjectWholeStaticLibsProperties struct {
+	Whole_static_libs []string
+}
+
+// StaticLibMembers is the ordered member object list of a static library dependency, as read
+// either from the archive itself (ar x) or from a Soong static lib's StaticLibraryInfo.Objects
+// provider.
+type StaticLibMembers struct {
+	LibName string
+	Objects []string
+}
+
+// ExtractWholeStaticLibMembers returns, in deterministic order (library order, then each
+// library's own member order), every object cc_object's partial link should include from its
+// whole_static_libs, erroring if two libraries contribute a member with the same name (the
+// partial link can't disambiguate them).
+func ExtractWholeStaticLibMembers(libs []StaticLibMembers) ([]string, error) {
+	var objects []string
+	seen := make(map[string]string) // member name -> owning lib
+
+	for _, lib := range libs {
+		for _, member := range lib.Objects {
+			if owner, ok := seen[member]; ok {
+				return nil, fmt.Errorf(
+					"whole_static_libs: member %q is provided by both %q and %q; "+
+						"rename one of the conflicting object files", member, owner, lib.LibName)
+			}
+			seen[member] = lib.LibName
+			objects = append(objects, member)
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,31 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractWholeStaticLibMembers_DeterministicOrder(t *testing.T) {
+	libs := []StaticLibMembers{
+		{LibName: "libsoong_static", Objects: []string{"a.o", "b.o"}},
+		{LibName: "libother", Objects: []string{"c.o"}},
+	}
+	got, err := ExtractWholeStaticLibMembers(libs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"a.o", "b.o", "c.o"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestExtractWholeStaticLibMembers_CollisionError(t *testing.T) {
+	libs := []StaticLibMembers{
+		{LibName: "liba", Objects: []string{"dup.o"}},
+		{LibName: "libb", Objects: []string{"dup.o"}},
+	}
+	if _, err := ExtractWholeStaticLibMembers(libs); err == nil {
+		t.Errorf("expected a collision error for duplicate member names")
+	}
+}
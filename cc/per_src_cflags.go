@@ -0,0 +1,46 @@
+package cc
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// PerSrcCflags is one `per_src_cflags` entry: extra cflags applied only to the sources matching
+// Srcs (glob patterns allowed), on top of the module's regular cflags.
+type PerSrcCflags struct {
+	Srcs   []string
+	Cflags []string
+}
+
+// PerSrcCflagsProperties holds the base compiler's `per_src_cflags` property.
+type PerSrcCflagsProperties struct {
+	Per_src_cflags []PerSrcCflags
+}
+
+// ResolvePerSrcCflags returns, for every source in moduleSrcs, the extra cflags (if any) that
+// should be appended after the module-wide cflags because a per_src_cflags entry matched it. It
+// errors if any entry's glob pattern matches none of moduleSrcs, since that almost always means a
+// stale or misspelled path.
+func ResolvePerSrcCflags(entries []PerSrcCflags, moduleSrcs []string) (map[string][]string, error) {
+	result := map[string][]string{}
+	for _, entry := range entries {
+		matchedAny := false
+		for _, pattern := range entry.Srcs {
+			for _, src := range moduleSrcs {
+				matched, err := filepath.Match(pattern, src)
+				if err != nil {
+					return nil, fmt.Errorf("per_src_cflags: invalid pattern %q: %w", pattern, err)
+				}
+				if !matched {
+					continue
+				}
+				matchedAny = true
+				result[src] = append(result[src], entry.Cflags...)
+			}
+		}
+		if !matchedAny {
+			return nil, fmt.Errorf("per_src_cflags: patterns %v matched no entries in srcs", entry.Srcs)
+		}
+	}
+	return result, nil
+}
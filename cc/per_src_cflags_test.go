@@ -0,0 +1,42 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolvePerSrcCflags_MatchesGlob(t *testing.T) {
+	entries := []PerSrcCflags{
+		{Srcs: []string{"legacy.cpp"}, Cflags: []string{"-fno-strict-aliasing"}},
+	}
+	got, err := ResolvePerSrcCflags(entries, []string{"legacy.cpp", "modern.cpp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{"legacy.cpp": {"-fno-strict-aliasing"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolvePerSrcCflags_UnmatchedPatternErrors(t *testing.T) {
+	entries := []PerSrcCflags{
+		{Srcs: []string{"nonexistent.cpp"}, Cflags: []string{"-foo"}},
+	}
+	if _, err := ResolvePerSrcCflags(entries, []string{"modern.cpp"}); err == nil {
+		t.Errorf("expected an error for an unmatched pattern")
+	}
+}
+
+func TestResolvePerSrcCflags_DoesNotAffectUnmatchedSrcs(t *testing.T) {
+	entries := []PerSrcCflags{
+		{Srcs: []string{"legacy.cpp"}, Cflags: []string{"-fno-strict-aliasing"}},
+	}
+	got, err := ResolvePerSrcCflags(entries, []string{"legacy.cpp", "modern.cpp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := got["modern.cpp"]; ok {
+		t.Errorf("did not expect modern.cpp to have extra flags")
+	}
+}
@@ -0,0 +1,49 @@
+package cc
+
+import "strings"
+
+// PrecompiledHeaderProperties holds the base compiler's PCH configuration, set via the
+// `precompiled_header` property on cc libraries.
+type PrecompiledHeaderProperties struct {
+	// Precompiled_header names the header to precompile, e.g. "foo_pch.h". Empty means PCH is
+	// disabled for this module.
+	Precompiled_header *string
+}
+
+// PrecompiledHeaderAction is the generated PCH compile action plus the flags every other source
+// compile in the module needs in order to consume it.
+type PrecompiledHeaderAction struct {
+	// Header is the source header being precompiled.
+	Header string
+	// Output is the generated .pch/.gch path, conventionally alongside the module's other
+	// per-arch intermediates.
+	Output string
+	// CompileFlags are appended to every non-stub, non-sabi-dump source compile in the module.
+	CompileFlags []string
+}
+
+func pchOutputPath(genDir, header string) string {
+	return genDir + "/" + strings.TrimSuffix(header, ".h") + ".pch"
+}
+
+// PrecompiledHeaderActionFor returns the PCH action for the module, or (PrecompiledHeaderAction{},
+// false) if the module has no precompiled_header set.
+func PrecompiledHeaderActionFor(props PrecompiledHeaderProperties, genDir string) (PrecompiledHeaderAction, bool) {
+	if props.Precompiled_header == nil || *props.Precompiled_header == "" {
+		return PrecompiledHeaderAction{}, false
+	}
+	header := *props.Precompiled_header
+	out := pchOutputPath(genDir, header)
+	return PrecompiledHeaderAction{
+		Header:       header,
+		Output:       out,
+		CompileFlags: []string{"-include-pch", out},
+	}, true
+}
+
+// PrecompiledHeaderApplies reports whether the PCH flags should be applied to a particular
+// compile: never for stubs builds (which strip -include flags in compilerFlags) and never for
+// sabi dump compiles, which must see the unmodified source.
+func PrecompiledHeaderApplies(isStubsCompile, isSabiDumpCompile bool) bool {
+	return !isStubsCompile && !isSabiDumpCompile
+}
@@ -0,0 +1,39 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrecompiledHeaderActionFor_Disabled(t *testing.T) {
+	if _, ok := PrecompiledHeaderActionFor(PrecompiledHeaderProperties{}, "out/gen"); ok {
+		t.Errorf("expected no PCH action without precompiled_header set")
+	}
+}
+
+func TestPrecompiledHeaderActionFor_Enabled(t *testing.T) {
+	header := "foo_pch.h"
+	action, ok := PrecompiledHeaderActionFor(PrecompiledHeaderProperties{Precompiled_header: &header}, "out/gen")
+	if !ok {
+		t.Fatalf("expected a PCH action")
+	}
+	if action.Output != "out/gen/foo_pch.pch" {
+		t.Errorf("got output %q", action.Output)
+	}
+	want := []string{"-include-pch", "out/gen/foo_pch.pch"}
+	if !reflect.DeepEqual(action.CompileFlags, want) {
+		t.Errorf("got flags %v, want %v", action.CompileFlags, want)
+	}
+}
+
+func TestPrecompiledHeaderApplies(t *testing.T) {
+	if !PrecompiledHeaderApplies(false, false) {
+		t.Errorf("expected PCH to apply to a regular compile")
+	}
+	if PrecompiledHeaderApplies(true, false) {
+		t.Errorf("expected PCH to be excluded from stubs compiles")
+	}
+	if PrecompiledHeaderApplies(false, true) {
+		t.Errorf("expected PCH to be excluded from sabi dump compiles")
+	}
+}
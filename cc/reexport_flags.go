@@ -0,0 +1,30 @@
+package cc
+
+import (
+	"fmt"
+
+	"android/soong/android"
+)
+
+// reexportFlagPrefixes are the flag forms that must never appear in a module's raw re-exported
+// flags: they belong in reexportDirs/reexportSystemDirs instead, where FlagExporter can emit
+// them relative to the right base path for every consumer.
+var reexportFlagPrefixes = []string{"-I", "-isystem"}
+
+// ValidateReexportFlags checks flags (a module's raw re-exported cflags, as opposed to its
+// reexportDirs/reexportSystemDirs) for any entry that looks like an include-path flag, which
+// should have been expressed via reexportDirs/reexportSystemDirs instead. It returns every
+// offending flag found rather than stopping at the first, so a ModuleErrorf can report them all
+// in one pass.
+func ValidateReexportFlags(flags []string) error {
+	var bad []string
+	for _, flag := range flags {
+		if android.PrefixInList(flag, reexportFlagPrefixes) {
+			bad = append(bad, flag)
+		}
+	}
+	if len(bad) == 0 {
+		return nil
+	}
+	return fmt.Errorf("export_flags must not contain include-path flags %v; use reexportDirs/reexportSystemDirs instead", bad)
+}
@@ -0,0 +1,23 @@
+package cc
+
+import "testing"
+
+func TestValidateReexportFlags_Clean(t *testing.T) {
+	if err := ValidateReexportFlags([]string{"-DFOO=1", "-Wall"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateReexportFlags_SingleBadFlag(t *testing.T) {
+	err := ValidateReexportFlags([]string{"-DFOO=1", "-Iinclude"})
+	if err == nil {
+		t.Fatalf("expected an error for a raw -I flag")
+	}
+}
+
+func TestValidateReexportFlags_MultipleBadFlags(t *testing.T) {
+	err := ValidateReexportFlags([]string{"-Iinclude", "-isystem system_include", "-Wall"})
+	if err == nil {
+		t.Fatalf("expected an error listing both bad flags")
+	}
+}
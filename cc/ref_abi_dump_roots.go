@@ -0,0 +1,70 @@
+package cc
+
+import "fmt"
+
+// AbiDumpClassification mirrors classifySourceAbiDump's output string, used to choose which
+// reference dump roots to search and in which order.
+type AbiDumpClassification string
+
+const (
+	AbiDumpPlatform AbiDumpClassification = "PLATFORM"
+	AbiDumpVndkCore AbiDumpClassification = "VNDK-core"
+	AbiDumpProduct  AbiDumpClassification = "PRODUCT"
+)
+
+// RefAbiDumpRoot is one directory PathForVndkRefAbiDump-style lookup may search, tagged with a
+// name for error messages.
+type RefAbiDumpRoot struct {
+	Name string
+	Dir  string
+}
+
+// RootsForClassification returns the ordered list of reference dump roots to search for a given
+// classification: PLATFORM dumps keyed by apiLevel, then product dumps, then vndk, filtered to
+// the roots relevant to classification.
+func RootsForClassification(classification AbiDumpClassification, apiLevel, productDir, vndkDir string) []RefAbiDumpRoot {
+	platform := RefAbiDumpRoot{Name: "platform", Dir: fmt.Sprintf("prebuilts/abi-dumps/platform/%s", apiLevel)}
+	product := RefAbiDumpRoot{Name: "product", Dir: productDir}
+	vndk := RefAbiDumpRoot{Name: "vndk", Dir: vndkDir}
+
+	switch classification {
+	case AbiDumpPlatform:
+		return []RefAbiDumpRoot{platform, product, vndk}
+	case AbiDumpProduct:
+		return []RefAbiDumpRoot{product, vndk}
+	default:
+		return []RefAbiDumpRoot{vndk}
+	}
+}
+
+// RefDumpExists abstracts the filesystem check PathForVndkRefAbiDump needs, so root resolution
+// can be tested without touching a real filesystem.
+type RefDumpExists func(root RefAbiDumpRoot, libraryName string) (path string, exists bool)
+
+// FindRefAbiDump searches roots in order for libraryName's reference dump, returning an error
+// naming both roots if more than one contains a dump (ambiguous reference), or the single match
+// otherwise. No match returns ("", false, nil).
+func FindRefAbiDump(roots []RefAbiDumpRoot, libraryName string, exists RefDumpExists) (path string, found bool, err error) {
+	var matchedRoot RefAbiDumpRoot
+	for _, root := range roots {
+		if p, ok := exists(root, libraryName); ok {
+			if found {
+				return "", false, fmt.Errorf("two reference dumps found for %s: one under %q, another under %q", libraryName, matchedRoot.Name, root.Name)
+			}
+			path = p
+			matchedRoot = root
+			found = true
+		}
+	}
+	return path, found, nil
+}
+
+// FindRefAbiDumpForDecision is FindRefAbiDump gated on ShouldCreateSourceAbiDump's decision: when
+// decision.Create is false there's no dump to compare against in the first place, so it skips the
+// root search entirely instead of reporting a misleading "not found".
+func FindRefAbiDumpForDecision(decision AbiDumpDecision, roots []RefAbiDumpRoot, libraryName string, exists RefDumpExists) (path string, found bool, err error) {
+	if !decision.Create {
+		return "", false, nil
+	}
+	return FindRefAbiDump(roots, libraryName, exists)
+}
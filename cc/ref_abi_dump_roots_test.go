@@ -0,0 +1,73 @@
+package cc
+
+import "testing"
+
+func TestRootsForClassification_Platform(t *testing.T) {
+	roots := RootsForClassification(AbiDumpPlatform, "30", "product/dir", "vndk/dir")
+	if len(roots) != 3 || roots[0].Name != "platform" {
+		t.Errorf("got %+v, expected platform first", roots)
+	}
+}
+
+func TestRootsForClassification_VndkCore(t *testing.T) {
+	roots := RootsForClassification(AbiDumpVndkCore, "30", "product/dir", "vndk/dir")
+	if len(roots) != 1 || roots[0].Name != "vndk" {
+		t.Errorf("got %+v, expected only vndk", roots)
+	}
+}
+
+func TestFindRefAbiDump_PlatformMatch(t *testing.T) {
+	roots := RootsForClassification(AbiDumpPlatform, "30", "product/dir", "vndk/dir")
+	exists := func(root RefAbiDumpRoot, lib string) (string, bool) {
+		if root.Name == "platform" {
+			return root.Dir + "/" + lib + ".so.lsdump", true
+		}
+		return "", false
+	}
+	path, found, err := FindRefAbiDump(roots, "libfoo", exists)
+	if err != nil || !found {
+		t.Fatalf("expected a match, got found=%v err=%v", found, err)
+	}
+	if path == "" {
+		t.Errorf("expected a non-empty path")
+	}
+}
+
+func TestFindRefAbiDump_AmbiguousError(t *testing.T) {
+	roots := RootsForClassification(AbiDumpPlatform, "30", "product/dir", "vndk/dir")
+	exists := func(root RefAbiDumpRoot, lib string) (string, bool) {
+		return root.Dir + "/" + lib + ".so.lsdump", true
+	}
+	_, _, err := FindRefAbiDump(roots, "libfoo", exists)
+	if err == nil {
+		t.Fatalf("expected an ambiguous-dump error when multiple roots match")
+	}
+}
+
+func TestFindRefAbiDumpForDecision_SkipsSearchWhenNotCreating(t *testing.T) {
+	roots := RootsForClassification(AbiDumpPlatform, "30", "product/dir", "vndk/dir")
+	exists := func(root RefAbiDumpRoot, lib string) (string, bool) {
+		t.Fatalf("exists should not be consulted when the dump decision is Create: false")
+		return "", false
+	}
+	decision := AbiDumpDecision{Create: false, Reason: AbiDumpReasonNotForPlatform}
+	path, found, err := FindRefAbiDumpForDecision(decision, roots, "libfoo", exists)
+	if err != nil || found || path != "" {
+		t.Errorf("got path=%q found=%v err=%v, want no-op", path, found, err)
+	}
+}
+
+func TestFindRefAbiDumpForDecision_SearchesWhenCreating(t *testing.T) {
+	roots := RootsForClassification(AbiDumpPlatform, "30", "product/dir", "vndk/dir")
+	exists := func(root RefAbiDumpRoot, lib string) (string, bool) {
+		if root.Name == "platform" {
+			return root.Dir + "/" + lib + ".so.lsdump", true
+		}
+		return "", false
+	}
+	decision := AbiDumpDecision{Create: true}
+	_, found, err := FindRefAbiDumpForDecision(decision, roots, "libfoo", exists)
+	if err != nil || !found {
+		t.Fatalf("expected a match, got found=%v err=%v", found, err)
+	}
+}
@@ -0,0 +1,56 @@
+package cc
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// RootIncludeExportPolicy holds the config-gated knobs for the export_include_dirs: ["."] policy
+// check.
+type RootIncludeExportPolicy struct {
+	// Enabled turns the check on at all; off by default until a branch opts in.
+	Enabled bool
+	// WarnOnly downgrades the violation to a warning instead of a hard error, for migration.
+	WarnOnly bool
+}
+
+// RootIncludeExportProperties is the subset of a module's properties the check needs.
+type RootIncludeExportProperties struct {
+	Export_include_dirs       []string
+	Allow_root_include_export *bool
+}
+
+func allowsRootIncludeExport(props RootIncludeExportProperties) bool {
+	return props.Allow_root_include_export != nil && *props.Allow_root_include_export
+}
+
+// isModuleRootDir reports whether dir, once cleaned, refers to the module's own directory rather
+// than a subdirectory of it: ".", "", or "./" all collapse to the module root.
+func isModuleRootDir(dir string) bool {
+	cleaned := filepath.Clean(dir)
+	return cleaned == "." || cleaned == ""
+}
+
+// ValidateRootIncludeExport errors (or returns a warning string, in WarnOnly mode) when the
+// module exports its own root directory via export_include_dirs without explicitly opting in via
+// allow_root_include_export, since that leaks every header in the tree and slows snapshot header
+// collection.
+func ValidateRootIncludeExport(policy RootIncludeExportPolicy, moduleName string, props RootIncludeExportProperties) (warning string, err error) {
+	if !policy.Enabled || allowsRootIncludeExport(props) {
+		return "", nil
+	}
+
+	for _, dir := range props.Export_include_dirs {
+		if isModuleRootDir(dir) {
+			msg := fmt.Sprintf(
+				"%s: export_include_dirs exports the module's own directory (%q); move the exported "+
+					"headers into an include/ subdirectory, or set allow_root_include_export: true",
+				moduleName, dir)
+			if policy.WarnOnly {
+				return msg, nil
+			}
+			return "", fmt.Errorf(msg)
+		}
+	}
+	return "", nil
+}
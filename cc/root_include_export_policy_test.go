@@ -0,0 +1,52 @@
+package cc
+
+import "testing"
+
+func boolPtrRootInclude(b bool) *bool { return &b }
+
+func TestValidateRootIncludeExport_RejectsDot(t *testing.T) {
+	policy := RootIncludeExportPolicy{Enabled: true}
+	props := RootIncludeExportProperties{Export_include_dirs: []string{"."}}
+	if _, err := ValidateRootIncludeExport(policy, "libfoo", props); err == nil {
+		t.Errorf("expected an error for exporting \".\"")
+	}
+}
+
+func TestValidateRootIncludeExport_RejectsEmptyString(t *testing.T) {
+	policy := RootIncludeExportPolicy{Enabled: true}
+	props := RootIncludeExportProperties{Export_include_dirs: []string{""}}
+	if _, err := ValidateRootIncludeExport(policy, "libfoo", props); err == nil {
+		t.Errorf("expected an error for exporting \"\"")
+	}
+}
+
+func TestValidateRootIncludeExport_AllowsSubdir(t *testing.T) {
+	policy := RootIncludeExportPolicy{Enabled: true}
+	props := RootIncludeExportProperties{Export_include_dirs: []string{"include"}}
+	if _, err := ValidateRootIncludeExport(policy, "libfoo", props); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateRootIncludeExport_OptOut(t *testing.T) {
+	policy := RootIncludeExportPolicy{Enabled: true}
+	props := RootIncludeExportProperties{
+		Export_include_dirs:       []string{"."},
+		Allow_root_include_export: boolPtrRootInclude(true),
+	}
+	if _, err := ValidateRootIncludeExport(policy, "libfoo", props); err != nil {
+		t.Errorf("unexpected error with allow_root_include_export: %v", err)
+	}
+}
+
+func TestValidateRootIncludeExport_WarnOnlyMode(t *testing.T) {
+	policy := RootIncludeExportPolicy{Enabled: true, WarnOnly: true}
+	props := RootIncludeExportProperties{Export_include_dirs: []string{"."}}
+	warning, err := ValidateRootIncludeExport(policy, "libfoo", props)
+	if err != nil {
+		t.Errorf("expected no hard error in warn-only mode, got: %v", err)
+	}
+	if warning == "" {
+		t.Errorf("expected a warning message in warn-only mode")
+	}
+}
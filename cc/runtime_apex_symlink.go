@@ -0,0 +1,46 @@
+package cc
+
+import "fmt"
+
+// defaultRuntimeApexName is used when the product config doesn't override it, matching today's
+// hard-coded behavior.
+const defaultRuntimeApexName = "com.android.runtime"
+
+// RuntimeApexConfig is the config hook installSymlinkToRuntimeApex reads to determine the
+// runtime apex's name, so devices using a renamed or versioned runtime apex don't get a dead
+// bootstrap symlink.
+type RuntimeApexConfig struct {
+	RuntimeApexName    string // product variable or DeviceConfig override; empty means default
+	ValidateApexExists bool
+	ProductApexNames   []string // every apex the product declares, used by validation
+}
+
+// RuntimeApexName returns the configured runtime apex name, falling back to the default.
+func RuntimeApexName(cfg RuntimeApexConfig) string {
+	if cfg.RuntimeApexName != "" {
+		return cfg.RuntimeApexName
+	}
+	return defaultRuntimeApexName
+}
+
+// InstallSymlinkToRuntimeApex returns the install command for the bootstrap symlink into the
+// runtime apex's lib dir, used both for the Soong install symlink and the make
+// post_install_cmds equivalent so they can't drift.
+func InstallSymlinkToRuntimeApex(cfg RuntimeApexConfig, relLibPath string) string {
+	return fmt.Sprintf("ln -sf /apex/%s/%s", RuntimeApexName(cfg), relLibPath)
+}
+
+// ValidateRuntimeApexExists checks that the configured runtime apex name is one the product
+// actually declares, when the check is enabled.
+func ValidateRuntimeApexExists(cfg RuntimeApexConfig) error {
+	if !cfg.ValidateApexExists {
+		return nil
+	}
+	name := RuntimeApexName(cfg)
+	for _, n := range cfg.ProductApexNames {
+		if n == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("runtime apex %q referenced by the bootstrap symlink is not declared by this product (declared: %v)", name, cfg.ProductApexNames)
+}
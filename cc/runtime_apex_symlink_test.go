@@ -0,0 +1,32 @@
+package cc
+
+import "testing"
+
+func TestRuntimeApexName_DefaultAndOverridden(t *testing.T) {
+	if got := RuntimeApexName(RuntimeApexConfig{}); got != "com.android.runtime" {
+		t.Errorf("got %q", got)
+	}
+	if got := RuntimeApexName(RuntimeApexConfig{RuntimeApexName: "com.acme.runtime"}); got != "com.acme.runtime" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestInstallSymlinkToRuntimeApex(t *testing.T) {
+	got := InstallSymlinkToRuntimeApex(RuntimeApexConfig{}, "lib64/libc.so")
+	want := "ln -sf /apex/com.android.runtime/lib64/libc.so"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateRuntimeApexExists(t *testing.T) {
+	cfg := RuntimeApexConfig{ValidateApexExists: true, ProductApexNames: []string{"com.android.runtime"}}
+	if err := ValidateRuntimeApexExists(cfg); err != nil {
+		t.Errorf("unexpected error for the default name: %v", err)
+	}
+
+	cfg.RuntimeApexName = "com.acme.runtime"
+	if err := ValidateRuntimeApexExists(cfg); err == nil {
+		t.Errorf("expected an error for an overridden name the product doesn't declare")
+	}
+}
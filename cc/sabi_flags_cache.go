@@ -0,0 +1,57 @@
+package cc
+
+// sourceAbiFlagsInputs is the data libraryDecorator.compile, linkSAbiDumpFiles and the stub path
+// each rebuild the SourceAbiFlags slice from; caching on this avoids recomputing it up to three
+// times per variant for libraries with hundreds of exported dirs.
+type sourceAbiFlagsInputs struct {
+	exportedIncludes []string
+	sabiIncludes     []string
+}
+
+// libraryDecoratorSabiCache is embedded style: callers construct one per libraryDecorator
+// variant and call SourceAbiFlags(), which computes the flag list once and reuses it for every
+// subsequent call with identical inputs.
+type libraryDecoratorSabiCache struct {
+	computed bool
+	inputs   sourceAbiFlagsInputs
+	flags    []string
+}
+
+// SourceAbiFlags returns the "-I" flag list for exportedIncludes plus the sabi-reexported
+// includes, computing it once per distinct (exportedIncludes, sabiIncludes) pair and reusing the
+// cached result otherwise.
+func (c *libraryDecoratorSabiCache) SourceAbiFlags(exportedIncludes, sabiIncludes []string) []string {
+	inputs := sourceAbiFlagsInputs{exportedIncludes: exportedIncludes, sabiIncludes: sabiIncludes}
+	if c.computed && sameSourceAbiInputs(c.inputs, inputs) {
+		return c.flags
+	}
+
+	flags := make([]string, 0, len(exportedIncludes)+len(sabiIncludes))
+	for _, dir := range exportedIncludes {
+		flags = append(flags, "-I"+dir)
+	}
+	for _, dir := range sabiIncludes {
+		flags = append(flags, "-I"+dir)
+	}
+
+	c.computed = true
+	c.inputs = inputs
+	c.flags = flags
+	return flags
+}
+
+func sameSourceAbiInputs(a, b sourceAbiFlagsInputs) bool {
+	return stringSlicesEqual(a.exportedIncludes, b.exportedIncludes) && stringSlicesEqual(a.sabiIncludes, b.sabiIncludes)
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
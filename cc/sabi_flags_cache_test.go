@@ -0,0 +1,43 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLibraryDecoratorSabiCache_MatchesThreeSiteComputation(t *testing.T) {
+	exported := []string{"include/a", "include/b"}
+	sabi := []string{"sabi/include"}
+
+	legacyCompute := func() []string {
+		var flags []string
+		for _, dir := range exported {
+			flags = append(flags, "-I"+dir)
+		}
+		for _, dir := range sabi {
+			flags = append(flags, "-I"+dir)
+		}
+		return flags
+	}
+
+	want := legacyCompute()
+
+	c := &libraryDecoratorSabiCache{}
+	fromCompile := c.SourceAbiFlags(exported, sabi)
+	fromSabiDump := c.SourceAbiFlags(exported, sabi)
+	fromStubs := c.SourceAbiFlags(exported, sabi)
+
+	if !reflect.DeepEqual(fromCompile, want) || !reflect.DeepEqual(fromSabiDump, want) || !reflect.DeepEqual(fromStubs, want) {
+		t.Errorf("got %v / %v / %v, want %v", fromCompile, fromSabiDump, fromStubs, want)
+	}
+}
+
+func TestLibraryDecoratorSabiCache_RecomputesOnChangedInputs(t *testing.T) {
+	c := &libraryDecoratorSabiCache{}
+	first := c.SourceAbiFlags([]string{"a"}, nil)
+	second := c.SourceAbiFlags([]string{"a", "b"}, nil)
+
+	if reflect.DeepEqual(first, second) {
+		t.Errorf("expected different flags for different inputs")
+	}
+}
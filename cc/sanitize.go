@@ -0,0 +1,15 @@
+package cc
+
+// SanitizeProperties aggregates every sanitizer-related property group a cc module can set, so
+// decisions that depend on "is any sanitizer enabled" (like where to install the variant) have
+// one place to check instead of each sanitizer mode answering that question independently.
+type SanitizeProperties struct {
+	Memtag MemtagProperties
+}
+
+// InSanitizerDir reports whether a module should install its variant under the sanitizer-specific
+// install dir rather than the regular partition path, true if any configured sanitizer mode
+// requires it.
+func InSanitizerDir(props SanitizeProperties) bool {
+	return memtagInSanitizerDir(props.Memtag)
+}
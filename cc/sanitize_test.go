@@ -0,0 +1,13 @@
+package cc
+
+import "testing"
+
+func TestInSanitizerDir_AggregatesMemtag(t *testing.T) {
+	heap := true
+	if !InSanitizerDir(SanitizeProperties{Memtag: MemtagProperties{Memtag_heap: &heap}}) {
+		t.Errorf("expected memtag_heap to route through the aggregated SanitizeProperties check")
+	}
+	if InSanitizerDir(SanitizeProperties{}) {
+		t.Errorf("expected no sanitizer dir when no sanitizer mode is enabled")
+	}
+}
@@ -0,0 +1,40 @@
+package cc
+
+// SharedLibraryStubsInfo is the typed provider linkShared populates per stubs variant, replacing
+// type-assertion-based access to the module for the symbol file and version metadata that linker
+// config generation and partner SDK tooling both need.
+type SharedLibraryStubsInfo struct {
+	// Version is the stub's own version string, e.g. "30" or "current".
+	Version string
+	// SymbolFile is the .map.txt the stub was generated from.
+	SymbolFile string
+	// MinVersion is the oldest version this stub is considered to still support, for consumers
+	// picking the lowest compatible stub in a multi-version chain.
+	MinVersion string
+}
+
+// AssembleSharedLibraryStubsInfos builds one SharedLibraryStubsInfo per stub variant, in the same
+// order linkShared assembles its stubs infos, deriving MinVersion as the lowest Version among all
+// the variants so every entry reports the chain's actual floor.
+func AssembleSharedLibraryStubsInfos(versions []string, symbolFile string) []SharedLibraryStubsInfo {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	minVersion := versions[0]
+	for _, v := range versions {
+		if v < minVersion {
+			minVersion = v
+		}
+	}
+
+	infos := make([]SharedLibraryStubsInfo, len(versions))
+	for i, v := range versions {
+		infos[i] = SharedLibraryStubsInfo{
+			Version:    v,
+			SymbolFile: symbolFile,
+			MinVersion: minVersion,
+		}
+	}
+	return infos
+}
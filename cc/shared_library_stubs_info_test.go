@@ -0,0 +1,24 @@
+package cc
+
+import "testing"
+
+func TestAssembleSharedLibraryStubsInfos_MinVersionAcrossChain(t *testing.T) {
+	infos := AssembleSharedLibraryStubsInfos([]string{"30", "29", "31"}, "foo.map.txt")
+	if len(infos) != 3 {
+		t.Fatalf("got %d infos, want 3", len(infos))
+	}
+	for _, info := range infos {
+		if info.MinVersion != "29" {
+			t.Errorf("got MinVersion %q, want 29", info.MinVersion)
+		}
+		if info.SymbolFile != "foo.map.txt" {
+			t.Errorf("got SymbolFile %q", info.SymbolFile)
+		}
+	}
+}
+
+func TestAssembleSharedLibraryStubsInfos_Empty(t *testing.T) {
+	if infos := AssembleSharedLibraryStubsInfos(nil, "foo.map.txt"); infos != nil {
+		t.Errorf("expected nil for no versions, got %v", infos)
+	}
+}
@@ -0,0 +1,79 @@
+package cc
+
+import "fmt"
+
+// SnapshotExcludeProperties holds the per-module opt-outs from vendor/recovery snapshots, for
+// modules (such as GPL-contaminated test utilities) that must never be captured even though they
+// live on a snapshotted path.
+type SnapshotExcludeProperties struct {
+	// Exclude_from_vendor_snapshot removes this module from the vendor snapshot.
+	Exclude_from_vendor_snapshot *bool
+
+	// Exclude_from_recovery_snapshot removes this module from the recovery snapshot.
+	Exclude_from_recovery_snapshot *bool
+}
+
+func (p *SnapshotExcludeProperties) excludedFromVendorSnapshot() bool {
+	return p.Exclude_from_vendor_snapshot != nil && *p.Exclude_from_vendor_snapshot
+}
+
+func (p *SnapshotExcludeProperties) excludedFromRecoverySnapshot() bool {
+	return p.Exclude_from_recovery_snapshot != nil && *p.Exclude_from_recovery_snapshot
+}
+
+// isVendorSnapshotModule reports whether a module should be captured in the vendor snapshot,
+// given its exclude property.
+func isVendorSnapshotModule(props SnapshotExcludeProperties) bool {
+	return !props.excludedFromVendorSnapshot()
+}
+
+// isRecoverySnapshotModule reports whether a module should be captured in the recovery
+// snapshot, given its exclude property.
+func isRecoverySnapshotModule(props SnapshotExcludeProperties) bool {
+	return !props.excludedFromRecoverySnapshot()
+}
+
+// SnapshotModule is the minimal view of a module's snapshot-relevant state needed to validate
+// exclude_from_*_snapshot against the exported-header dependency graph.
+type SnapshotModule struct {
+	Name               string
+	Props              SnapshotExcludeProperties
+	ExportedHeaderDeps []string // names of modules this module's exported headers depend on
+}
+
+// ValidateVendorSnapshotExcludes checks that no included module's exported headers depend on an
+// excluded module, which would make the resulting vendor snapshot unbuildable. It returns an
+// error naming the first such conflict found.
+func ValidateVendorSnapshotExcludes(modules []SnapshotModule) error {
+	return validateSnapshotExcludes(modules, isVendorSnapshotModule, "vendor")
+}
+
+// ValidateRecoverySnapshotExcludes is the recovery-snapshot analog of
+// ValidateVendorSnapshotExcludes.
+func ValidateRecoverySnapshotExcludes(modules []SnapshotModule) error {
+	return validateSnapshotExcludes(modules, isRecoverySnapshotModule, "recovery")
+}
+
+func validateSnapshotExcludes(modules []SnapshotModule, included func(SnapshotExcludeProperties) bool, snapshotKind string) error {
+	excluded := make(map[string]bool)
+	byName := make(map[string]SnapshotModule, len(modules))
+	for _, m := range modules {
+		byName[m.Name] = m
+		if !included(m.Props) {
+			excluded[m.Name] = true
+		}
+	}
+
+	for _, m := range modules {
+		if !included(m.Props) {
+			continue
+		}
+		for _, dep := range m.ExportedHeaderDeps {
+			if excluded[dep] {
+				return fmt.Errorf("%s snapshot: module %q is excluded from the %s snapshot but is required by %q's exported headers",
+					snapshotKind, dep, snapshotKind, m.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package cc
+
+import "testing"
+
+func TestIsVendorSnapshotModule_PlainExclusion(t *testing.T) {
+	if isVendorSnapshotModule(SnapshotExcludeProperties{Exclude_from_vendor_snapshot: boolPtr(true)}) {
+		t.Errorf("expected excluded module to not be a vendor snapshot module")
+	}
+	if !isVendorSnapshotModule(SnapshotExcludeProperties{}) {
+		t.Errorf("expected non-excluded module to be a vendor snapshot module")
+	}
+}
+
+func TestIsRecoverySnapshotModule_PlainExclusion(t *testing.T) {
+	if isRecoverySnapshotModule(SnapshotExcludeProperties{Exclude_from_recovery_snapshot: boolPtr(true)}) {
+		t.Errorf("expected excluded module to not be a recovery snapshot module")
+	}
+}
+
+func TestValidateVendorSnapshotExcludes_NoConflict(t *testing.T) {
+	modules := []SnapshotModule{
+		{Name: "libtestutil", Props: SnapshotExcludeProperties{Exclude_from_vendor_snapshot: boolPtr(true)}},
+		{Name: "libfoo", ExportedHeaderDeps: []string{"libbar"}},
+		{Name: "libbar"},
+	}
+	if err := ValidateVendorSnapshotExcludes(modules); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateVendorSnapshotExcludes_ConflictingDependency(t *testing.T) {
+	modules := []SnapshotModule{
+		{Name: "libtestutil", Props: SnapshotExcludeProperties{Exclude_from_vendor_snapshot: boolPtr(true)}},
+		{Name: "libfoo", ExportedHeaderDeps: []string{"libtestutil"}},
+	}
+	err := ValidateVendorSnapshotExcludes(modules)
+	if err == nil {
+		t.Fatalf("expected an error when an included module's exported headers depend on an excluded module")
+	}
+}
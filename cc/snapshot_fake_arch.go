@@ -0,0 +1,46 @@
+package cc
+
+// FakeSnapshotLibraryEntry is the JSON representation of a placeholder snapshot entry
+// generated for an arch that is configured but was not actually built. Consumers of the
+// snapshot's Android.bp (the prebuilt loader) key off Fake to disable the variant instead of
+// linking against an empty archive.
+type FakeSnapshotLibraryEntry struct {
+	ModuleName string `json:"module_name"`
+	Arch       string `json:"arch"`
+	Fake       bool   `json:"fake"`
+}
+
+// GenerateFakeSnapshotEntries returns one FakeSnapshotLibraryEntry per (module, arch) pair in
+// configuredArches that does not appear in builtArches, so the snapshot's prebuilt Android.bp
+// keeps a parseable reference for every configured arch even when only a subset was actually
+// built. Order follows configuredArches.
+func GenerateFakeSnapshotEntries(moduleName string, configuredArches, builtArches []string) []FakeSnapshotLibraryEntry {
+	built := make(map[string]bool, len(builtArches))
+	for _, a := range builtArches {
+		built[a] = true
+	}
+
+	var fakes []FakeSnapshotLibraryEntry
+	for _, arch := range configuredArches {
+		if built[arch] {
+			continue
+		}
+		fakes = append(fakes, FakeSnapshotLibraryEntry{
+			ModuleName: moduleName,
+			Arch:       arch,
+			Fake:       true,
+		})
+	}
+	return fakes
+}
+
+// IsFakeSnapshotVariant reports whether the prebuilt loader should disable the module variant
+// for arch, because it was only ever emitted as a fake placeholder entry.
+func IsFakeSnapshotVariant(entries []FakeSnapshotLibraryEntry, arch string) bool {
+	for _, e := range entries {
+		if e.Arch == arch && e.Fake {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package cc
+
+import "testing"
+
+func TestGenerateFakeSnapshotEntries_TwoArchOneBuilt(t *testing.T) {
+	entries := GenerateFakeSnapshotEntries("libfoo", []string{"arm", "arm64"}, []string{"arm64"})
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 fake entry, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Arch != "arm" || !entries[0].Fake || entries[0].ModuleName != "libfoo" {
+		t.Errorf("unexpected fake entry: %+v", entries[0])
+	}
+}
+
+func TestGenerateFakeSnapshotEntries_AllBuilt(t *testing.T) {
+	entries := GenerateFakeSnapshotEntries("libfoo", []string{"arm", "arm64"}, []string{"arm", "arm64"})
+	if len(entries) != 0 {
+		t.Errorf("expected no fake entries when all arches were built, got %+v", entries)
+	}
+}
+
+func TestIsFakeSnapshotVariant(t *testing.T) {
+	entries := GenerateFakeSnapshotEntries("libfoo", []string{"arm", "arm64"}, []string{"arm64"})
+	if !IsFakeSnapshotVariant(entries, "arm") {
+		t.Errorf("expected arm variant to be reported fake")
+	}
+	if IsFakeSnapshotVariant(entries, "arm64") {
+		t.Errorf("did not expect arm64 variant to be reported fake")
+	}
+}
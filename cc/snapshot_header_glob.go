@@ -0,0 +1,80 @@
+package cc
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SnapshotHeaderGlobProperties lets a module exclude subtrees (e.g. test/) from the header
+// collection a snapshot walks when capturing a library's headers.
+type SnapshotHeaderGlobProperties struct {
+	// Exclude_header_dirs lists glob patterns (matched against the path relative to the
+	// collected directory) to exclude from snapshot header collection, e.g. "test/**".
+	Exclude_header_dirs []string
+}
+
+// HeaderGlobCache memoizes one GlobWithDeps-style directory walk per directory within a module,
+// so collectHeadersForSnapshot globs each directory once instead of once per extension.
+type HeaderGlobCache struct {
+	mu    sync.Mutex
+	files map[string][]string // dir -> every file found under dir
+}
+
+// NewHeaderGlobCache returns an empty HeaderGlobCache, scoped to one module's snapshot header
+// collection.
+func NewHeaderGlobCache() *HeaderGlobCache {
+	return &HeaderGlobCache{files: map[string][]string{}}
+}
+
+// allFiles returns every file under dir, calling glob (a GlobWithDeps-shaped function) only the
+// first time dir is requested.
+func (c *HeaderGlobCache) allFiles(dir string, glob func(dir string) []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if files, ok := c.files[dir]; ok {
+		return files
+	}
+	files := glob(dir)
+	c.files[dir] = files
+	return files
+}
+
+// CollectHeadersForSnapshot walks each directory in dirs once (via cache), filters the resulting
+// files by extension in Go instead of issuing one glob per extension, and drops any file that
+// matches an entry of excludePatterns relative to its directory.
+func CollectHeadersForSnapshot(dirs []string, extensions []string, excludePatterns []string, cache *HeaderGlobCache, glob func(dir string) []string) []string {
+	extSet := make(map[string]bool, len(extensions))
+	for _, e := range extensions {
+		extSet[e] = true
+	}
+
+	var headers []string
+	for _, dir := range dirs {
+		for _, f := range cache.allFiles(dir, glob) {
+			if !extSet[filepath.Ext(f)] {
+				continue
+			}
+			rel := strings.TrimPrefix(strings.TrimPrefix(f, dir), "/")
+			if matchesAnyGlob(rel, excludePatterns) {
+				continue
+			}
+			headers = append(headers, f)
+		}
+	}
+	return headers
+}
+
+func matchesAnyGlob(path string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, path); ok {
+			return true
+		}
+		// filepath.Match doesn't support "**"; fall back to a simple prefix check for patterns
+		// of the form "dir/**".
+		if strings.HasSuffix(p, "/**") && strings.HasPrefix(path, strings.TrimSuffix(p, "**")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollectHeadersForSnapshot_ExcludesTestDirs(t *testing.T) {
+	cache := NewHeaderGlobCache()
+	glob := func(dir string) []string {
+		return []string{
+			dir + "/foo.h",
+			dir + "/test/bar.h",
+			dir + "/foo.cpp",
+		}
+	}
+
+	got := CollectHeadersForSnapshot([]string{"include"}, []string{".h"}, []string{"test/**"}, cache, glob)
+	want := []string{"include/foo.h"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCollectHeadersForSnapshot_GlobsEachDirectoryOnce(t *testing.T) {
+	cache := NewHeaderGlobCache()
+	globCalls := 0
+	glob := func(dir string) []string {
+		globCalls++
+		return []string{dir + "/foo.h", dir + "/foo.hpp"}
+	}
+
+	CollectHeadersForSnapshot([]string{"include"}, []string{".h"}, nil, cache, glob)
+	CollectHeadersForSnapshot([]string{"include"}, []string{".hpp"}, nil, cache, glob)
+
+	if globCalls != 1 {
+		t.Errorf("expected the directory to be globbed exactly once across multiple extension passes, got %d calls", globCalls)
+	}
+}
@@ -0,0 +1,52 @@
+package cc
+
+// WholeStaticLibProvenance records one whole_static_libs module absorbed into a snapshot static
+// library's flattened archive, along with its notice file, so license attribution and later
+// deduplication can trace archive content back to its source module.
+type WholeStaticLibProvenance struct {
+	ModuleName string
+	NoticeFile string
+}
+
+// SnapshotStaticLibraryEntry is the snapshot metadata for one static library, extended with the
+// provenance of every whole_static_libs module flattened into its archive.
+type SnapshotStaticLibraryEntry struct {
+	ModuleName      string
+	NoticeFile      string
+	WholeStaticLibs []WholeStaticLibProvenance
+}
+
+// CollectWholeStaticLibProvenance walks the whole_static_libs chain starting at the direct deps
+// of a module (as already resolved by linkStatic when it copies WholeStaticLibObjs) and returns
+// the provenance of every module absorbed, including transitively absorbed whole_static_libs of
+// those deps, in dependency order.
+func CollectWholeStaticLibProvenance(deps []WholeStaticLibDep) []WholeStaticLibProvenance {
+	var provenance []WholeStaticLibProvenance
+	for _, dep := range deps {
+		provenance = append(provenance, WholeStaticLibProvenance{
+			ModuleName: dep.ModuleName,
+			NoticeFile: dep.NoticeFile,
+		})
+		provenance = append(provenance, CollectWholeStaticLibProvenance(dep.WholeStaticLibs)...)
+	}
+	return provenance
+}
+
+// WholeStaticLibDep is the minimal view of a whole_static_libs dependency needed to trace
+// provenance: its own identity plus whatever it, in turn, absorbed.
+type WholeStaticLibDep struct {
+	ModuleName      string
+	NoticeFile      string
+	WholeStaticLibs []WholeStaticLibDep
+}
+
+// CombineSnapshotNotices returns the notice files to combine for entry: the top-level module's
+// own notice plus the notice of every whole_static_libs module it absorbed, rather than only the
+// top-level notice.
+func CombineSnapshotNotices(entry SnapshotStaticLibraryEntry) []string {
+	notices := []string{entry.NoticeFile}
+	for _, w := range entry.WholeStaticLibs {
+		notices = append(notices, w.NoticeFile)
+	}
+	return notices
+}
@@ -0,0 +1,44 @@
+package cc
+
+import "testing"
+
+func TestCollectWholeStaticLibProvenance_TwoLevelChain(t *testing.T) {
+	deps := []WholeStaticLibDep{
+		{
+			ModuleName: "libinner",
+			NoticeFile: "libinner/NOTICE",
+			WholeStaticLibs: []WholeStaticLibDep{
+				{ModuleName: "libinnermost", NoticeFile: "libinnermost/NOTICE"},
+			},
+		},
+	}
+
+	got := CollectWholeStaticLibProvenance(deps)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 provenance entries for a two-level chain, got %d: %+v", len(got), got)
+	}
+	if got[0].ModuleName != "libinner" || got[1].ModuleName != "libinnermost" {
+		t.Errorf("unexpected provenance order: %+v", got)
+	}
+}
+
+func TestCombineSnapshotNotices_IncludesAbsorbedNotices(t *testing.T) {
+	entry := SnapshotStaticLibraryEntry{
+		ModuleName: "libtop",
+		NoticeFile: "libtop/NOTICE",
+		WholeStaticLibs: []WholeStaticLibProvenance{
+			{ModuleName: "libinner", NoticeFile: "libinner/NOTICE"},
+		},
+	}
+
+	got := CombineSnapshotNotices(entry)
+	want := []string{"libtop/NOTICE", "libinner/NOTICE"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cc
+
+import "fmt"
+
+// CoverageMode selects between the two mutually exclusive coverage instrumentation strategies a
+// module can build with.
+type CoverageMode int
+
+const (
+	// CoverageModeGcov is the existing gcno-based coverage, producing a coverageOutputFile zip.
+	CoverageModeGcov CoverageMode = iota
+	// CoverageModeClangSourceBased uses -fprofile-instr-generate -fcoverage-mapping; no gcno zip
+	// is produced, and the unstripped binary itself is the coverage artifact.
+	CoverageModeClangSourceBased
+)
+
+// CoverageCompileFlags returns the compile flags for mode.
+func CoverageCompileFlags(mode CoverageMode) []string {
+	switch mode {
+	case CoverageModeClangSourceBased:
+		return []string{"-fprofile-instr-generate", "-fcoverage-mapping"}
+	default:
+		return []string{"--coverage"}
+	}
+}
+
+// ProducesCoverageZip reports whether mode produces a gcno zip artifact at all; source-based
+// coverage instead registers the unstripped binary with the coverage provider.
+func ProducesCoverageZip(mode CoverageMode) bool {
+	return mode == CoverageModeGcov
+}
+
+// ValidateCoverageModeConsistency errors if a static dependency chain mixes coverage modes, since
+// linking gcov-instrumented and clang-source-instrumented objects together produces meaningless
+// counters.
+func ValidateCoverageModeConsistency(moduleName string, moduleMode CoverageMode, depName string, depMode CoverageMode) error {
+	if moduleMode != depMode {
+		return fmt.Errorf("%s: coverage mode mismatch with static dep %s (module is %v, dep is %v); "+
+			"all modules in a static dependency chain must use the same coverage mode",
+			moduleName, depName, moduleMode, depMode)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoverageCompileFlags_PerMode(t *testing.T) {
+	gcov := CoverageCompileFlags(CoverageModeGcov)
+	if !reflect.DeepEqual(gcov, []string{"--coverage"}) {
+		t.Errorf("got %v", gcov)
+	}
+	clang := CoverageCompileFlags(CoverageModeClangSourceBased)
+	want := []string{"-fprofile-instr-generate", "-fcoverage-mapping"}
+	if !reflect.DeepEqual(clang, want) {
+		t.Errorf("got %v, want %v", clang, want)
+	}
+}
+
+func TestProducesCoverageZip(t *testing.T) {
+	if !ProducesCoverageZip(CoverageModeGcov) {
+		t.Errorf("expected gcov mode to produce a zip")
+	}
+	if ProducesCoverageZip(CoverageModeClangSourceBased) {
+		t.Errorf("expected clang source-based mode to not produce a zip")
+	}
+}
+
+func TestValidateCoverageModeConsistency_MismatchErrors(t *testing.T) {
+	err := ValidateCoverageModeConsistency("libfoo", CoverageModeGcov, "libbar", CoverageModeClangSourceBased)
+	if err == nil {
+		t.Errorf("expected an error for mismatched coverage modes")
+	}
+}
+
+func TestValidateCoverageModeConsistency_MatchOk(t *testing.T) {
+	err := ValidateCoverageModeConsistency("libfoo", CoverageModeGcov, "libbar", CoverageModeGcov)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
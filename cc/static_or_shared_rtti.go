@@ -0,0 +1,43 @@
+package cc
+
+// StaticOrSharedProperties holds the per-linkage-variant compiler overrides that apply only to
+// the static or only to the shared sub-variant of a library, layered on top of the module-level
+// settings in compilerFlags.
+type StaticOrSharedProperties struct {
+	Cflags   []string
+	Cppflags []string
+	Rtti     *bool
+}
+
+// ResolveRtti returns the effective -frtti/-fno-rtti setting for a sub-variant: the sub-variant's
+// Rtti override if set, otherwise the module-level default.
+func ResolveRtti(moduleRtti bool, sub StaticOrSharedProperties) bool {
+	if sub.Rtti != nil {
+		return *sub.Rtti
+	}
+	return moduleRtti
+}
+
+// RttiFlag returns the compile flag for an effective RTTI setting.
+func RttiFlag(rtti bool) string {
+	if rtti {
+		return "-frtti"
+	}
+	return "-fno-rtti"
+}
+
+// MergedSubVariantCppflags returns the module-level cppflags followed by the sub-variant's own,
+// so sub-variant flags win on conflict by appearing later on the command line.
+func MergedSubVariantCppflags(moduleCppflags []string, sub StaticOrSharedProperties) []string {
+	merged := make([]string, 0, len(moduleCppflags)+len(sub.Cppflags))
+	merged = append(merged, moduleCppflags...)
+	merged = append(merged, sub.Cppflags...)
+	return merged
+}
+
+// RttiDiffersAcrossVariants reports whether the static and shared sub-variants have different
+// effective RTTI settings, meaning reuseStaticLibrary must not share compiled objects between
+// them.
+func RttiDiffersAcrossVariants(moduleRtti bool, static, shared StaticOrSharedProperties) bool {
+	return ResolveRtti(moduleRtti, static) != ResolveRtti(moduleRtti, shared)
+}
@@ -0,0 +1,38 @@
+package cc
+
+import "testing"
+
+func boolPtrRtti(b bool) *bool { return &b }
+
+func TestResolveRtti_OverrideWins(t *testing.T) {
+	sub := StaticOrSharedProperties{Rtti: boolPtrRtti(false)}
+	if ResolveRtti(true, sub) {
+		t.Errorf("expected sub-variant override to disable RTTI")
+	}
+}
+
+func TestResolveRtti_FallsBackToModuleDefault(t *testing.T) {
+	if !ResolveRtti(true, StaticOrSharedProperties{}) {
+		t.Errorf("expected module-level default to apply")
+	}
+}
+
+func TestRttiFlag(t *testing.T) {
+	if RttiFlag(true) != "-frtti" {
+		t.Errorf("got %q", RttiFlag(true))
+	}
+	if RttiFlag(false) != "-fno-rtti" {
+		t.Errorf("got %q", RttiFlag(false))
+	}
+}
+
+func TestRttiDiffersAcrossVariants(t *testing.T) {
+	static := StaticOrSharedProperties{Rtti: boolPtrRtti(false)}
+	shared := StaticOrSharedProperties{}
+	if !RttiDiffersAcrossVariants(true, static, shared) {
+		t.Errorf("expected static/shared RTTI settings to differ")
+	}
+	if RttiDiffersAcrossVariants(true, StaticOrSharedProperties{}, StaticOrSharedProperties{}) {
+		t.Errorf("expected no difference without overrides")
+	}
+}
@@ -0,0 +1,56 @@
+package cc
+
+// StlImageVariant identifies which image variant an stl selection override applies to.
+type StlImageVariant string
+
+const (
+	StlImageCore     StlImageVariant = "core"
+	StlImageVendor   StlImageVariant = "vendor"
+	StlImageProduct  StlImageVariant = "product"
+	StlImageRecovery StlImageVariant = "recovery"
+)
+
+// StlProperties holds the module-level `stl` property plus its per-image-variant overrides set
+// via `target: { <image>: { stl: "..." } }`.
+type StlProperties struct {
+	Stl    string
+	Target map[StlImageVariant]string
+}
+
+// ResolveStl returns the effective stl selection for imageVariant: its override if one was set
+// under target, otherwise the module-level default.
+func ResolveStl(props StlProperties, imageVariant StlImageVariant) string {
+	if override, ok := props.Target[imageVariant]; ok && override != "" {
+		return override
+	}
+	return props.Stl
+}
+
+// StlAppliesToVariant reports whether per-image STL selection should apply at all: never for
+// stubs variants, which null out their stl selection entirely in createVersionVariations.
+func StlAppliesToVariant(isStubsVariant bool) bool {
+	return !isStubsVariant
+}
+
+// ValidateStaticAnalogueStlConsistency errors if a shared library and the static analogue
+// referenced via StaticAnalogue in SharedLibraryInfo resolve to different STLs for the same image
+// variant, since a shared library and its static counterpart must agree on which STL
+// implementation their consumers link against.
+func ValidateStaticAnalogueStlConsistency(moduleName string, sharedStl, staticStl string, imageVariant StlImageVariant) error {
+	if sharedStl != staticStl {
+		return &stlConsistencyError{ModuleName: moduleName, ImageVariant: imageVariant, SharedStl: sharedStl, StaticStl: staticStl}
+	}
+	return nil
+}
+
+type stlConsistencyError struct {
+	ModuleName   string
+	ImageVariant StlImageVariant
+	SharedStl    string
+	StaticStl    string
+}
+
+func (e *stlConsistencyError) Error() string {
+	return e.ModuleName + ": shared/static STL mismatch for image variant " + string(e.ImageVariant) +
+		" (shared=" + e.SharedStl + ", static=" + e.StaticStl + ")"
+}
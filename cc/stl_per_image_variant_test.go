@@ -0,0 +1,31 @@
+package cc
+
+import "testing"
+
+func TestResolveStl_RecoveryOverride(t *testing.T) {
+	props := StlProperties{Stl: "c++_shared", Target: map[StlImageVariant]string{StlImageRecovery: "c++_static"}}
+	if got := ResolveStl(props, StlImageRecovery); got != "c++_static" {
+		t.Errorf("got %q, want c++_static", got)
+	}
+	if got := ResolveStl(props, StlImageCore); got != "c++_shared" {
+		t.Errorf("got %q, want c++_shared", got)
+	}
+}
+
+func TestStlAppliesToVariant(t *testing.T) {
+	if StlAppliesToVariant(true) {
+		t.Errorf("expected per-image STL selection to not apply to stubs variants")
+	}
+	if !StlAppliesToVariant(false) {
+		t.Errorf("expected per-image STL selection to apply to regular variants")
+	}
+}
+
+func TestValidateStaticAnalogueStlConsistency(t *testing.T) {
+	if err := ValidateStaticAnalogueStlConsistency("libfoo", "c++_shared", "c++_static", StlImageCore); err == nil {
+		t.Errorf("expected an error for a shared/static STL mismatch")
+	}
+	if err := ValidateStaticAnalogueStlConsistency("libfoo", "c++_static", "c++_static", StlImageRecovery); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package cc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StubVersionMacro is one stub library's exported API version macro, as would otherwise be
+// passed to a consumer as a raw "-D__X_API__=n" flag.
+type StubVersionMacro struct {
+	MacroName string
+	Version   string
+}
+
+// GenerateStubVersionsHeader renders the force-included "soong_stub_versions.h" content for a
+// consumer linking against macros, with entries sorted by macro name so the header content (and
+// therefore its hash) is deterministic per-variant and only changes when a version actually
+// changes, rather than when dependency order changes.
+func GenerateStubVersionsHeader(macros []StubVersionMacro) string {
+	sorted := append([]StubVersionMacro(nil), macros...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MacroName < sorted[j].MacroName })
+
+	var b strings.Builder
+	b.WriteString("// Generated by soong. Do not edit.\n")
+	b.WriteString("#pragma once\n")
+	for _, m := range sorted {
+		fmt.Fprintf(&b, "#define %s %s\n", m.MacroName, m.Version)
+	}
+	return b.String()
+}
+
+// StubVersionsConsumerCflags returns the cflags a consumer should use under the aggregated
+// header mode: a single stable force-include flag, instead of one "-D" flag per stub macro.
+func StubVersionsConsumerCflags(headerPath string, aggregatedMode bool, macros []StubVersionMacro) []string {
+	if aggregatedMode {
+		return []string{"-include", headerPath}
+	}
+	flags := make([]string, 0, len(macros))
+	for _, m := range macros {
+		flags = append(flags, fmt.Sprintf("-D%s=%s", m.MacroName, m.Version))
+	}
+	return flags
+}
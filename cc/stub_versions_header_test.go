@@ -0,0 +1,63 @@
+package cc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"android/soong/android"
+)
+
+func TestGenerateStubVersionsHeader_DeterministicAcrossOrder(t *testing.T) {
+	a := []StubVersionMacro{{MacroName: "__B_API__", Version: "29"}, {MacroName: "__A_API__", Version: "30"}}
+	b := []StubVersionMacro{{MacroName: "__A_API__", Version: "30"}, {MacroName: "__B_API__", Version: "29"}}
+
+	if GenerateStubVersionsHeader(a) != GenerateStubVersionsHeader(b) {
+		t.Errorf("expected header content to be independent of input order")
+	}
+}
+
+func TestGenerateStubVersionsHeader_ChangesOnVersionBump(t *testing.T) {
+	before := GenerateStubVersionsHeader([]StubVersionMacro{{MacroName: "__A_API__", Version: "29"}})
+	after := GenerateStubVersionsHeader([]StubVersionMacro{{MacroName: "__A_API__", Version: "30"}})
+	if before == after {
+		t.Errorf("expected header content to change when a version bumps")
+	}
+}
+
+func TestStubVersionsConsumerCflags_AggregatedModeIsStable(t *testing.T) {
+	macrosBefore := []StubVersionMacro{{MacroName: "__A_API__", Version: "29"}}
+	macrosAfter := []StubVersionMacro{{MacroName: "__A_API__", Version: "30"}}
+
+	cflagsBefore := StubVersionsConsumerCflags("soong_stub_versions.h", true, macrosBefore)
+	cflagsAfter := StubVersionsConsumerCflags("soong_stub_versions.h", true, macrosAfter)
+	if !reflect.DeepEqual(cflagsBefore, cflagsAfter) {
+		t.Errorf("expected consumer cflags to stay stable across a version bump in aggregated mode, got %v vs %v", cflagsBefore, cflagsAfter)
+	}
+}
+
+func TestStubVersionsConsumerCflags_LegacyModeChurns(t *testing.T) {
+	cflags := StubVersionsConsumerCflags("", false, []StubVersionMacro{{MacroName: "__A_API__", Version: "30"}})
+	if !strings.Contains(cflags[0], "-D__A_API__=30") {
+		t.Errorf("got %v", cflags)
+	}
+}
+
+// TestGenerateStubVersionsHeader_PreviewCodenameFromApiLevel exercises the real
+// android.ApiLevelFromUser parse path feeding a stub macro version, to make sure a preview
+// codename (not just a numbered release) round-trips into the generated header as its codename
+// rather than the numeric FutureApiLevel sentinel.
+func TestGenerateStubVersionsHeader_PreviewCodenameFromApiLevel(t *testing.T) {
+	lvl, err := android.ApiLevelFromUser("R", []string{"Q", "R"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !lvl.IsPreview() {
+		t.Fatalf("expected %+v to be a preview level", lvl)
+	}
+
+	header := GenerateStubVersionsHeader([]StubVersionMacro{{MacroName: "__FOO_API__", Version: lvl.Codename}})
+	if !strings.Contains(header, "#define __FOO_API__ R") {
+		t.Errorf("expected the preview codename in the generated header, got:\n%s", header)
+	}
+}
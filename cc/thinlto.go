@@ -0,0 +1,65 @@
+package cc
+
+import "fmt"
+
+// LtoProperties holds the `lto` property block.
+type LtoProperties struct {
+	Lto struct {
+		Thin *bool
+	}
+}
+
+// ThinLtoEnabled reports whether the module opted into ThinLTO.
+func ThinLtoEnabled(props LtoProperties) bool {
+	return props.Lto.Thin != nil && *props.Lto.Thin
+}
+
+// ThinLtoCompileFlags returns the compile-time flags for a ThinLTO module.
+func ThinLtoCompileFlags(props LtoProperties) []string {
+	if !ThinLtoEnabled(props) {
+		return nil
+	}
+	return []string{"-flto=thin"}
+}
+
+// ThinLtoLdFlags returns the link-time flags for a ThinLTO module, pointing the cache at a single
+// shared directory per product so concurrent links reuse each other's cached work instead of each
+// maintaining its own cache.
+func ThinLtoLdFlags(props LtoProperties, sharedCacheDir string) []string {
+	if !ThinLtoEnabled(props) {
+		return nil
+	}
+	return []string{"-Wl,--thinlto-cache-dir=" + sharedCacheDir}
+}
+
+// ThinLtoCachePruningFlags returns the cache pruning policy flags appended alongside
+// --thinlto-cache-dir, bounding how large the shared cache is allowed to grow.
+func ThinLtoCachePruningFlags(maxCacheSizeBytes, pruningIntervalSeconds int) []string {
+	return []string{
+		fmt.Sprintf("-Wl,--thinlto-cache-policy=cache_size_bytes=%d:prune_interval=%ds", maxCacheSizeBytes, pruningIntervalSeconds),
+	}
+}
+
+// ThinLtoDisablesObjectReuse reports whether the module's .thinlto.bc intermediates prevent
+// reuseStaticLibrary from sharing compiled objects between the static and shared variants: ThinLTO
+// bitcode objects from one variant aren't valid inputs for the other's non-LTO link.
+func ThinLtoDisablesObjectReuse(props LtoProperties) bool {
+	return ThinLtoEnabled(props)
+}
+
+// ValidateThinLtoSanitizerInteraction errors if the module enables a sanitizer/CFI combination
+// that requires LTO while ThinLTO itself is disabled, since CFI and some sanitizer modes can only
+// instrument at link time with LTO active.
+func ValidateThinLtoSanitizerInteraction(props LtoProperties, requiresLto bool, moduleName string) error {
+	if requiresLto && !ThinLtoEnabled(props) {
+		return fmt.Errorf("%s: sanitizer/CFI configuration requires lto.thin: true", moduleName)
+	}
+	return nil
+}
+
+// ThinLtoAppliesToStubsVariant reports whether ThinLTO flags should apply to a stubs variant:
+// never, since stubs compiles don't produce a real link and have no LTO-relevant code to
+// optimize.
+func ThinLtoAppliesToStubsVariant(isStubsVariant bool) bool {
+	return !isStubsVariant
+}
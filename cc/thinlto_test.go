@@ -0,0 +1,60 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func boolPtrThinLto(b bool) *bool { return &b }
+
+func TestThinLtoCompileAndLdFlags(t *testing.T) {
+	props := LtoProperties{}
+	props.Lto.Thin = boolPtrThinLto(true)
+
+	if got := ThinLtoCompileFlags(props); !reflect.DeepEqual(got, []string{"-flto=thin"}) {
+		t.Errorf("got %v", got)
+	}
+	if got := ThinLtoLdFlags(props, "/out/thinlto_cache"); !reflect.DeepEqual(got, []string{"-Wl,--thinlto-cache-dir=/out/thinlto_cache"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestThinLtoFlags_DisabledIsNoop(t *testing.T) {
+	if got := ThinLtoCompileFlags(LtoProperties{}); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+	if got := ThinLtoLdFlags(LtoProperties{}, "/out/thinlto_cache"); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
+
+func TestThinLtoDisablesObjectReuse(t *testing.T) {
+	props := LtoProperties{}
+	props.Lto.Thin = boolPtrThinLto(true)
+	if !ThinLtoDisablesObjectReuse(props) {
+		t.Errorf("expected ThinLTO to disable object reuse")
+	}
+	if ThinLtoDisablesObjectReuse(LtoProperties{}) {
+		t.Errorf("expected no effect when ThinLTO disabled")
+	}
+}
+
+func TestValidateThinLtoSanitizerInteraction(t *testing.T) {
+	if err := ValidateThinLtoSanitizerInteraction(LtoProperties{}, true, "libfoo"); err == nil {
+		t.Errorf("expected an error when CFI requires LTO but thin is disabled")
+	}
+	props := LtoProperties{}
+	props.Lto.Thin = boolPtrThinLto(true)
+	if err := ValidateThinLtoSanitizerInteraction(props, true, "libfoo"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestThinLtoAppliesToStubsVariant(t *testing.T) {
+	if ThinLtoAppliesToStubsVariant(true) {
+		t.Errorf("expected ThinLTO to not apply to stubs variants")
+	}
+	if !ThinLtoAppliesToStubsVariant(false) {
+		t.Errorf("expected ThinLTO to apply to regular variants")
+	}
+}
@@ -0,0 +1,70 @@
+package cc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// TidyBaselineProperties holds the `tidy` baseline-gating properties.
+type TidyBaselineProperties struct {
+	Tidy struct {
+		Baseline             *string
+		Fail_on_new_findings *bool
+	}
+}
+
+// FailOnNewTidyFindings reports whether the module is gated on having no new tidy findings.
+func FailOnNewTidyFindings(props TidyBaselineProperties) bool {
+	return props.Tidy.Fail_on_new_findings != nil && *props.Tidy.Fail_on_new_findings
+}
+
+// outSoongPathRe strips the build-specific out/soong prefix from a tidy finding's path so the
+// diff against the checked-in baseline is stable across out dir locations.
+var outSoongPathRe = regexp.MustCompile(`^.*?out/soong/[^/]+/`)
+
+// NormalizeTidyFindingPath strips build-path prefixes from a tidy finding line so it can be
+// compared against the checked-in baseline independent of where the build happened.
+func NormalizeTidyFindingPath(finding string) string {
+	return outSoongPathRe.ReplaceAllString(finding, "")
+}
+
+// DiffTidyFindings returns the findings present in current but not in baseline, after
+// normalizing both, sorted for a deterministic error message.
+func DiffTidyFindings(baseline, current []string) []string {
+	baselineSet := map[string]bool{}
+	for _, f := range baseline {
+		baselineSet[NormalizeTidyFindingPath(f)] = true
+	}
+
+	var newFindings []string
+	for _, f := range current {
+		normalized := NormalizeTidyFindingPath(f)
+		if !baselineSet[normalized] {
+			newFindings = append(newFindings, normalized)
+		}
+	}
+	sort.Strings(newFindings)
+	return newFindings
+}
+
+// ValidateTidyBaseline errors listing every new finding when fail_on_new_findings is set and the
+// diff against baseline is non-empty.
+func ValidateTidyBaseline(props TidyBaselineProperties, baseline, current []string) error {
+	if !FailOnNewTidyFindings(props) {
+		return nil
+	}
+	newFindings := DiffTidyFindings(baseline, current)
+	if len(newFindings) == 0 {
+		return nil
+	}
+	return fmt.Errorf("clang-tidy: %d new finding(s) not in baseline %s: %v",
+		len(newFindings), stringOrEmpty(props.Tidy.Baseline), newFindings)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
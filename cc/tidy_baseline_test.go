@@ -0,0 +1,39 @@
+package cc
+
+import "testing"
+
+func boolPtrTidy(b bool) *bool { return &b }
+
+func TestNormalizeTidyFindingPath_StripsOutSoongPrefix(t *testing.T) {
+	got := NormalizeTidyFindingPath("/home/user/out/soong/.intermediates/foo/foo.cpp:10: warning")
+	want := "foo/foo.cpp:10: warning"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDiffTidyFindings_OnlyNew(t *testing.T) {
+	baseline := []string{"out/soong/x/a.cpp:1: warn"}
+	current := []string{"out/soong/x/a.cpp:1: warn", "out/soong/x/b.cpp:2: warn"}
+	got := DiffTidyFindings(baseline, current)
+	if len(got) != 1 || got[0] != "b.cpp:2: warn" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestValidateTidyBaseline_FailsOnNewFindings(t *testing.T) {
+	props := TidyBaselineProperties{}
+	props.Tidy.Fail_on_new_findings = boolPtrTidy(true)
+	baseline := []string{"a.cpp:1: warn"}
+	current := []string{"a.cpp:1: warn", "b.cpp:2: warn"}
+	if err := ValidateTidyBaseline(props, baseline, current); err == nil {
+		t.Errorf("expected an error for a new finding")
+	}
+}
+
+func TestValidateTidyBaseline_NoopWithoutFailOnNewFindings(t *testing.T) {
+	props := TidyBaselineProperties{}
+	if err := ValidateTidyBaseline(props, nil, []string{"b.cpp:2: warn"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package cc
+
+import "fmt"
+
+// UndefineStubApiMacrosProperties holds the consumer-side `undefine_stub_api_macros` property.
+type UndefineStubApiMacrosProperties struct {
+	// Undefine_stub_api_macros names deps whose exported API version macro should be undefined
+	// again after the define, for consumers whose own code misbehaves when the macro is merely
+	// defined at all.
+	Undefine_stub_api_macros []string
+}
+
+// ValidateUndefineStubApiMacros errors if a listed dep doesn't actually export a stub version
+// macro, since undefining a macro that was never defined is a sign the property references the
+// wrong dep name.
+func ValidateUndefineStubApiMacros(props UndefineStubApiMacrosProperties, depMacros map[string]StubVersionMacro) error {
+	for _, dep := range props.Undefine_stub_api_macros {
+		if _, ok := depMacros[dep]; !ok {
+			return fmt.Errorf("undefine_stub_api_macros: %q does not export a stub API version macro", dep)
+		}
+	}
+	return nil
+}
+
+// UndefineStubApiMacrosFlags returns the "-U" flags to append after the exported define flags,
+// one per listed dep that has a known macro, in the order the property lists them.
+func UndefineStubApiMacrosFlags(props UndefineStubApiMacrosProperties, depMacros map[string]StubVersionMacro) []string {
+	var flags []string
+	for _, dep := range props.Undefine_stub_api_macros {
+		if macro, ok := depMacros[dep]; ok {
+			flags = append(flags, "-U"+macro.MacroName)
+		}
+	}
+	return flags
+}
@@ -0,0 +1,33 @@
+package cc
+
+import "testing"
+
+func TestValidateUndefineStubApiMacros_UnknownDepErrors(t *testing.T) {
+	props := UndefineStubApiMacrosProperties{Undefine_stub_api_macros: []string{"libfoo"}}
+	if err := ValidateUndefineStubApiMacros(props, map[string]StubVersionMacro{}); err == nil {
+		t.Errorf("expected an error for an unknown dep")
+	}
+}
+
+func TestValidateUndefineStubApiMacros_KnownDepOk(t *testing.T) {
+	props := UndefineStubApiMacrosProperties{Undefine_stub_api_macros: []string{"libfoo"}}
+	depMacros := map[string]StubVersionMacro{"libfoo": {MacroName: "__FOO_API__", Version: "30"}}
+	if err := ValidateUndefineStubApiMacros(props, depMacros); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUndefineStubApiMacrosFlags(t *testing.T) {
+	props := UndefineStubApiMacrosProperties{Undefine_stub_api_macros: []string{"libfoo"}}
+	depMacros := map[string]StubVersionMacro{"libfoo": {MacroName: "__FOO_API__", Version: "30"}}
+	got := UndefineStubApiMacrosFlags(props, depMacros)
+	if len(got) != 1 || got[0] != "-U__FOO_API__" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestUndefineStubApiMacrosFlags_EmptyWithoutProperty(t *testing.T) {
+	if got := UndefineStubApiMacrosFlags(UndefineStubApiMacrosProperties{}, nil); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package cc
+
+import "fmt"
+
+// UseLinkerProperties holds the per-module linker selection for linkerFlags.
+type UseLinkerProperties struct {
+	// Use_linker selects which linker drives this module's links: "lld", "bfd", or "gold". Empty
+	// means the toolchain default.
+	Use_linker *string
+}
+
+func (p *UseLinkerProperties) linker() string {
+	if p.Use_linker == nil {
+		return ""
+	}
+	return *p.Use_linker
+}
+
+// toolchainLinkers are the linkers a toolchain is assumed to provide; a real toolchain type would
+// report this itself, but cc's test doubles and the validation below only need the set.
+var toolchainLinkers = map[string]bool{
+	"lld":  true,
+	"bfd":  true,
+	"gold": true,
+}
+
+// ValidateUseLinker checks that the requested linker is one the toolchain provides.
+func ValidateUseLinker(props UseLinkerProperties, toolchainProvides []string) error {
+	linker := props.linker()
+	if linker == "" {
+		return nil
+	}
+	if !toolchainLinkers[linker] {
+		return fmt.Errorf("use_linker: %q is not a recognized linker (want one of lld, bfd, gold)", linker)
+	}
+	for _, l := range toolchainProvides {
+		if l == linker {
+			return nil
+		}
+	}
+	return fmt.Errorf("use_linker: toolchain does not provide %q (provides %v)", linker, toolchainProvides)
+}
+
+// UseLinkerFlags returns the -fuse-ld= flag linkerFlags should add for the module's linker
+// selection, or nil when the toolchain default should be used.
+func UseLinkerFlags(props UseLinkerProperties) []string {
+	linker := props.linker()
+	if linker == "" {
+		return nil
+	}
+	return []string{"-fuse-ld=" + linker}
+}
+
+// LinkerRequirement records that a module's link depends on a linker feature only some linkers
+// support (e.g. lld's --pack-dyn-relocs=android+relr), so ValidateLinkerRequirement can catch an
+// incompatible use_linker selection at build time instead of a cryptic link failure.
+type LinkerRequirement struct {
+	Feature        string
+	RequiredLinker string
+}
+
+// ValidateLinkerRequirement errors when a module requires a linker feature but use_linker selects
+// a different, incompatible linker.
+func ValidateLinkerRequirement(props UseLinkerProperties, req LinkerRequirement) error {
+	linker := props.linker()
+	if linker == "" || linker == req.RequiredLinker {
+		return nil
+	}
+	return fmt.Errorf("use_linker: %q requires the %s linker for %s, but use_linker is set to %q",
+		req.Feature, req.RequiredLinker, req.Feature, linker)
+}
@@ -0,0 +1,45 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestUseLinkerFlags(t *testing.T) {
+	if got := UseLinkerFlags(UseLinkerProperties{}); got != nil {
+		t.Errorf("expected no flags for unset use_linker, got %v", got)
+	}
+	got := UseLinkerFlags(UseLinkerProperties{Use_linker: strPtr("lld")})
+	want := []string{"-fuse-ld=lld"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestValidateUseLinker(t *testing.T) {
+	if err := ValidateUseLinker(UseLinkerProperties{Use_linker: strPtr("lld")}, []string{"lld", "bfd"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateUseLinker(UseLinkerProperties{Use_linker: strPtr("bfd")}, []string{"lld"}); err == nil {
+		t.Errorf("expected an error when toolchain doesn't provide the requested linker")
+	}
+	if err := ValidateUseLinker(UseLinkerProperties{Use_linker: strPtr("mold")}, []string{"lld", "bfd", "gold"}); err == nil {
+		t.Errorf("expected an error for an unrecognized linker")
+	}
+}
+
+func TestValidateLinkerRequirement_IncompatibilityError(t *testing.T) {
+	req := LinkerRequirement{Feature: "--pack-dyn-relocs=android+relr", RequiredLinker: "lld"}
+
+	if err := ValidateLinkerRequirement(UseLinkerProperties{Use_linker: strPtr("bfd")}, req); err == nil {
+		t.Errorf("expected an error for bfd with an lld-only feature")
+	}
+	if err := ValidateLinkerRequirement(UseLinkerProperties{Use_linker: strPtr("lld")}, req); err != nil {
+		t.Errorf("unexpected error for lld: %v", err)
+	}
+	if err := ValidateLinkerRequirement(UseLinkerProperties{}, req); err != nil {
+		t.Errorf("unexpected error for unset use_linker: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package cc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VersionHeaderProperties holds the `version_header` compiler feature property.
+type VersionHeaderProperties struct {
+	Version_header struct {
+		Out    string
+		Fields []string
+	}
+}
+
+// VersionHeaderEnabled reports whether the module requested a generated version header.
+func VersionHeaderEnabled(props VersionHeaderProperties) bool {
+	return props.Version_header.Out != ""
+}
+
+// knownVersionFields are the build-version values version_header can expose, shared with the
+// existing use_version_lib build-number plumbing.
+var knownVersionFields = map[string]bool{
+	"soong_build_number": true,
+	"module_name":        true,
+	"git_revision":       true,
+}
+
+// ValidateVersionHeaderFields errors on any field name not recognized.
+func ValidateVersionHeaderFields(fields []string) error {
+	for _, f := range fields {
+		if !knownVersionFields[f] {
+			return fmt.Errorf("version_header: unknown field %q", f)
+		}
+	}
+	return nil
+}
+
+// GenerateVersionHeader renders the constexpr-string header content for the requested fields,
+// given their resolved values, with fields emitted in the order requested (not sorted), so the
+// header's layout is predictable from the property list.
+func GenerateVersionHeader(moduleName string, fields []string, values map[string]string) string {
+	var b strings.Builder
+	b.WriteString("// Generated by soong. Do not edit.\n")
+	b.WriteString("#pragma once\n\n")
+	for _, f := range fields {
+		fmt.Fprintf(&b, "constexpr char %s[] = \"%s\";\n", f, values[f])
+	}
+	return b.String()
+}
+
+// VersionHeaderRebuildKey returns a stable string capturing only the requested fields' values,
+// so the generation rule only needs to rerun when one of those values actually changes, not on
+// every build.
+func VersionHeaderRebuildKey(fields []string, values map[string]string) string {
+	sorted := append([]string(nil), fields...)
+	sort.Strings(sorted)
+	var b strings.Builder
+	for _, f := range sorted {
+		fmt.Fprintf(&b, "%s=%s;", f, values[f])
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package cc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateVersionHeaderFields_RejectsUnknown(t *testing.T) {
+	if err := ValidateVersionHeaderFields([]string{"bogus_field"}); err == nil {
+		t.Errorf("expected an error for an unknown field")
+	}
+}
+
+func TestValidateVersionHeaderFields_AllowsKnown(t *testing.T) {
+	if err := ValidateVersionHeaderFields([]string{"soong_build_number", "module_name"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGenerateVersionHeader_ContainsRequestedFields(t *testing.T) {
+	values := map[string]string{"soong_build_number": "12345", "module_name": "libfoo"}
+	got := GenerateVersionHeader("libfoo", []string{"soong_build_number", "module_name"}, values)
+	if !strings.Contains(got, `constexpr char soong_build_number[] = "12345";`) {
+		t.Errorf("got %s", got)
+	}
+	if !strings.Contains(got, `constexpr char module_name[] = "libfoo";`) {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestVersionHeaderRebuildKey_StableAcrossFieldOrder(t *testing.T) {
+	values := map[string]string{"a": "1", "b": "2"}
+	k1 := VersionHeaderRebuildKey([]string{"a", "b"}, values)
+	k2 := VersionHeaderRebuildKey([]string{"b", "a"}, values)
+	if k1 != k2 {
+		t.Errorf("got %q vs %q, want stable across field order", k1, k2)
+	}
+}
+
+func TestVersionHeaderRebuildKey_ChangesWithValue(t *testing.T) {
+	k1 := VersionHeaderRebuildKey([]string{"a"}, map[string]string{"a": "1"})
+	k2 := VersionHeaderRebuildKey([]string{"a"}, map[string]string{"a": "2"})
+	if k1 == k2 {
+		t.Errorf("expected rebuild key to change when value changes")
+	}
+}
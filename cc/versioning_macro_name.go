@@ -0,0 +1,76 @@
+package cc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var macroUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// versioningMacroName uppercases moduleName and squashes punctuation into underscores to build
+// the "__X_API__" stub-versioning macro, e.g. "lib-foo" and "lib_foo" both become
+// "__LIB_FOO_API__".
+func versioningMacroName(moduleName string) string {
+	squashed := macroUnsafeChars.ReplaceAllString(moduleName, "_")
+	return "__" + strings.ToUpper(squashed) + "_API__"
+}
+
+// versioningMacroNamesList tracks which module first registered each macro name, so a later
+// registrant colliding with an earlier one can be caught (or, with the opt-in below,
+// disambiguated) instead of two modules silently sharing a macro.
+var versioningMacroNamesList = struct {
+	mu    sync.Mutex
+	names map[string]string // macro name -> first module name that claimed it
+}{names: map[string]string{}}
+
+// VersioningMacroSuffixAuto is the versioning_macro_suffix property value that opts a module into
+// automatic disambiguation instead of a hard collision error.
+const VersioningMacroSuffixAuto = "auto"
+
+// disambiguationHashLen is short enough to stay readable in a macro name while still being
+// effectively collision-free across a single build's module set.
+const disambiguationHashLen = 6
+
+// RegisterVersioningMacroName claims the macro name for moduleName's stub API macro. If the name
+// is already claimed by a different module: when versioningMacroSuffix is
+// VersioningMacroSuffixAuto, a short stable hash of moduleName is appended to disambiguate and
+// the disambiguated name is returned; otherwise a collision error is returned as today.
+func RegisterVersioningMacroName(moduleName, versioningMacroSuffix string) (string, error) {
+	versioningMacroNamesList.mu.Lock()
+	defer versioningMacroNamesList.mu.Unlock()
+
+	name := versioningMacroName(moduleName)
+	owner, claimed := versioningMacroNamesList.names[name]
+	if !claimed || owner == moduleName {
+		versioningMacroNamesList.names[name] = moduleName
+		return name, nil
+	}
+
+	if versioningMacroSuffix != VersioningMacroSuffixAuto {
+		return "", fmt.Errorf(
+			"versioning macro %q for module %q collides with module %q; "+
+				"rename one of the modules, or set versioning_macro_suffix: \"auto\" on %q",
+			name, moduleName, owner, moduleName)
+	}
+
+	disambiguated := disambiguateMacroName(name, moduleName)
+	versioningMacroNamesList.names[disambiguated] = moduleName
+	return disambiguated, nil
+}
+
+func disambiguateMacroName(name, moduleName string) string {
+	sum := sha256.Sum256([]byte(moduleName))
+	suffix := strings.ToUpper(hex.EncodeToString(sum[:]))[:disambiguationHashLen]
+	return strings.TrimSuffix(name, "_API__") + "_" + suffix + "_API__"
+}
+
+// ResetVersioningMacroNamesListForTests clears the registry between test cases.
+func ResetVersioningMacroNamesListForTests() {
+	versioningMacroNamesList.mu.Lock()
+	defer versioningMacroNamesList.mu.Unlock()
+	versioningMacroNamesList.names = map[string]string{}
+}
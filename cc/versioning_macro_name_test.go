@@ -0,0 +1,49 @@
+package cc
+
+import "testing"
+
+func TestRegisterVersioningMacroName_CollisionWithoutOptOut(t *testing.T) {
+	ResetVersioningMacroNamesListForTests()
+	defer ResetVersioningMacroNamesListForTests()
+
+	if _, err := RegisterVersioningMacroName("lib-foo", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := RegisterVersioningMacroName("lib_foo", ""); err == nil {
+		t.Errorf("expected a collision error for lib-foo vs lib_foo")
+	}
+}
+
+func TestRegisterVersioningMacroName_AutoDisambiguates(t *testing.T) {
+	ResetVersioningMacroNamesListForTests()
+	defer ResetVersioningMacroNamesListForTests()
+
+	first, err := RegisterVersioningMacroName("lib-foo", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := RegisterVersioningMacroName("lib_foo", VersioningMacroSuffixAuto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first == second {
+		t.Errorf("expected distinct disambiguated macro names, got %q for both", first)
+	}
+}
+
+func TestRegisterVersioningMacroName_StableAcrossCalls(t *testing.T) {
+	ResetVersioningMacroNamesListForTests()
+	RegisterVersioningMacroName("lib-foo", "")
+	got1, err1 := RegisterVersioningMacroName("lib_foo", VersioningMacroSuffixAuto)
+
+	ResetVersioningMacroNamesListForTests()
+	RegisterVersioningMacroName("lib-foo", "")
+	got2, err2 := RegisterVersioningMacroName("lib_foo", VersioningMacroSuffixAuto)
+
+	if err1 != nil || err2 != nil {
+		t.Fatalf("unexpected errors: %v, %v", err1, err2)
+	}
+	if got1 != got2 {
+		t.Errorf("expected the disambiguated macro name to be stable across builds, got %q and %q", got1, got2)
+	}
+}
@@ -0,0 +1,38 @@
+package cc
+
+// VndkClassification is the computed image classification for one cc module variant, summarizing
+// several mutator decisions (vndk, vndk-sp, vndk-private, vndk-ext, llndk, useVndk image) that
+// today are re-derived inconsistently by classifySourceAbiDump, install subdir selection and the
+// module-info JSON writer.
+type VndkClassification struct {
+	IsVndk        bool
+	IsVndkSp      bool
+	IsVndkPrivate bool
+	IsVndkExt     bool
+	IsLlndk       bool
+	UseVndk       bool
+}
+
+// VndkClassificationInputs is the subset of a cc module's vndk-related properties and mutator
+// state needed to compute its VndkClassification.
+type VndkClassificationInputs struct {
+	VndkEnabled   bool
+	IsVndkSpLib   bool
+	VndkPrivate   bool
+	IsExtension   bool
+	IsLlndkLib    bool
+	ImageUsesVndk bool // true for vendor/product variants that link against the VNDK
+}
+
+// ComputeVndkClassification derives a module variant's VndkClassification from its raw property
+// and mutator inputs, in one place, so every consumer agrees on the result.
+func ComputeVndkClassification(in VndkClassificationInputs) VndkClassification {
+	return VndkClassification{
+		IsVndk:        in.VndkEnabled,
+		IsVndkSp:      in.VndkEnabled && in.IsVndkSpLib,
+		IsVndkPrivate: in.VndkEnabled && in.VndkPrivate,
+		IsVndkExt:     in.IsExtension,
+		IsLlndk:       in.IsLlndkLib,
+		UseVndk:       in.ImageUsesVndk,
+	}
+}
@@ -0,0 +1,45 @@
+package cc
+
+import "testing"
+
+func TestComputeVndkClassification(t *testing.T) {
+	tests := []struct {
+		name string
+		in   VndkClassificationInputs
+		want VndkClassification
+	}{
+		{
+			name: "plain vendor-available, not vndk",
+			in:   VndkClassificationInputs{ImageUsesVndk: true},
+			want: VndkClassification{UseVndk: true},
+		},
+		{
+			name: "vndk-sp",
+			in:   VndkClassificationInputs{VndkEnabled: true, IsVndkSpLib: true},
+			want: VndkClassification{IsVndk: true, IsVndkSp: true},
+		},
+		{
+			name: "vndk-private",
+			in:   VndkClassificationInputs{VndkEnabled: true, VndkPrivate: true},
+			want: VndkClassification{IsVndk: true, IsVndkPrivate: true},
+		},
+		{
+			name: "llndk",
+			in:   VndkClassificationInputs{IsLlndkLib: true},
+			want: VndkClassification{IsLlndk: true},
+		},
+		{
+			name: "vndk extension",
+			in:   VndkClassificationInputs{VndkEnabled: true, IsExtension: true},
+			want: VndkClassification{IsVndk: true, IsVndkExt: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeVndkClassification(tt.in)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
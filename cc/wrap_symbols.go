@@ -0,0 +1,54 @@
+package cc
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// WrapSymbolsProperties holds the base linker's `wrap_symbols` property.
+type WrapSymbolsProperties struct {
+	Wrap_symbols []string
+}
+
+// symbolNameRe matches a valid C symbol name, which is all --wrap accepts.
+var symbolNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateWrapSymbols errors on any entry that isn't a syntactically valid symbol name.
+func ValidateWrapSymbols(symbols []string) error {
+	for _, s := range symbols {
+		if !symbolNameRe.MatchString(s) {
+			return fmt.Errorf("wrap_symbols: %q is not a valid symbol name", s)
+		}
+	}
+	return nil
+}
+
+// WrapSymbolsLdFlags returns the -Wl,--wrap= flags for the listed symbols, one per symbol, in
+// property order.
+func WrapSymbolsLdFlags(symbols []string) []string {
+	flags := make([]string, len(symbols))
+	for i, s := range symbols {
+		flags[i] = "-Wl,--wrap=" + s
+	}
+	return flags
+}
+
+// WrappedAbiSymbolNames returns the __wrap_ and __real_ symbol names the ABI checker should
+// ignore in linkSAbiDumpFiles for the listed wrapped symbols, since they're linker-generated
+// interposition thunks rather than part of the module's real ABI surface.
+func WrappedAbiSymbolNames(symbols []string) []string {
+	names := make([]string, 0, len(symbols)*2)
+	for _, s := range symbols {
+		names = append(names, "__wrap_"+s, "__real_"+s)
+	}
+	return names
+}
+
+// ValidateWrapSymbolsHostOS errors when wrap_symbols is set for a Darwin target, since ld64 has
+// no equivalent to --wrap.
+func ValidateWrapSymbolsHostOS(symbols []string, hostOS string) error {
+	if len(symbols) > 0 && hostOS == "darwin" {
+		return fmt.Errorf("wrap_symbols: not supported on darwin (ld64 has no --wrap equivalent)")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateWrapSymbols_InvalidNameErrors(t *testing.T) {
+	if err := ValidateWrapSymbols([]string{"malloc()"}); err == nil {
+		t.Errorf("expected an error for an invalid symbol name")
+	}
+}
+
+func TestValidateWrapSymbols_ValidNamesOk(t *testing.T) {
+	if err := ValidateWrapSymbols([]string{"malloc", "free"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWrapSymbolsLdFlags(t *testing.T) {
+	got := WrapSymbolsLdFlags([]string{"malloc", "free"})
+	want := []string{"-Wl,--wrap=malloc", "-Wl,--wrap=free"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWrappedAbiSymbolNames(t *testing.T) {
+	got := WrappedAbiSymbolNames([]string{"malloc"})
+	want := []string{"__wrap_malloc", "__real_malloc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestValidateWrapSymbolsHostOS_RejectsDarwin(t *testing.T) {
+	if err := ValidateWrapSymbolsHostOS([]string{"malloc"}, "darwin"); err == nil {
+		t.Errorf("expected an error on darwin")
+	}
+	if err := ValidateWrapSymbolsHostOS([]string{"malloc"}, "linux"); err != nil {
+		t.Errorf("unexpected error on linux: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package cc
+
+// SnapshotHeaderExts are the extensions CollectHeadersForSnapshot should glob for when capturing
+// a library's exported headers, including assembly-with-preprocessor fragments (.inc) alongside
+// the usual C/C++ headers.
+var SnapshotHeaderExts = []string{".h", ".hh", ".hpp", ".inc"}
+
+// YasmProperties is the subset of a module's assembler config YasmFlags needs.
+type YasmProperties struct {
+	ExportedIncludeDirs []string
+}
+
+// YasmFlags returns the -I flags yasm should use for a .S source, covering the module's own
+// exported include dirs so .inc fragments exported by other modules resolve the same way clang's
+// preprocessor does for compilerFlags.
+func YasmFlags(props YasmProperties) []string {
+	flags := make([]string, 0, len(props.ExportedIncludeDirs)*2)
+	for _, dir := range props.ExportedIncludeDirs {
+		flags = append(flags, "-I", dir)
+	}
+	return flags
+}
+
+// YasmDepfileFlags returns the flags that make yasm emit a depfile naming every .inc file it
+// actually included, so edits to an exported .inc retrigger assembly of consumers across module
+// boundaries, the same way C/C++ compiles already depend on their included headers.
+func YasmDepfileFlags(depfilePath string) []string {
+	return []string{"-M", depfilePath}
+}
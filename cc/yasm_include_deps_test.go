@@ -0,0 +1,34 @@
+package cc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestYasmFlags_ExportedIncludeDirs(t *testing.T) {
+	got := YasmFlags(YasmProperties{ExportedIncludeDirs: []string{"foo/include", "bar/include"}})
+	want := []string{"-I", "foo/include", "-I", "bar/include"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestYasmDepfileFlags(t *testing.T) {
+	got := YasmDepfileFlags("out/foo.d")
+	want := []string{"-M", "out/foo.d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSnapshotHeaderExts_IncludesIncExtension(t *testing.T) {
+	found := false
+	for _, ext := range SnapshotHeaderExts {
+		if ext == ".inc" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected SnapshotHeaderExts to include .inc, got %v", SnapshotHeaderExts)
+	}
+}
@@ -0,0 +1,45 @@
+package genrule
+
+import "fmt"
+
+// AndroidMkEntry is a minimal stand-in for android.AndroidMkEntry, carrying just the fields
+// genrule's mk output depends on, so BuildAndroidMkEntries can be unit tested without the full
+// module/config machinery.
+type AndroidMkEntry struct {
+	Class        string
+	OutputFile   string
+	ExtraEntries map[string]string
+	PhonyAlias   string
+}
+
+// BuildAndroidMkEntries replaces genrule's legacy AndroidMkData Custom writer: it returns one
+// primary AndroidMkEntry naming the module's first output plus one ExtraEntries-style entry per
+// additional output, so multi-output genrules get automatic dist support and LOCAL_LICENSE
+// plumbing instead of requiring a hand-written Custom function. The phony alias used for module
+// variants (subName) is preserved on the primary entry so existing `.PHONY` dependents keep
+// working unchanged.
+func BuildAndroidMkEntries(moduleName, subName string, outputs []string) ([]AndroidMkEntry, error) {
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("genrule %q: no outputs to emit androidmk entries for", moduleName)
+	}
+
+	primary := AndroidMkEntry{
+		Class:      "ETC",
+		OutputFile: outputs[0],
+	}
+	if subName != "" {
+		primary.PhonyAlias = moduleName + subName
+	}
+
+	entries := []AndroidMkEntry{primary}
+	for i, out := range outputs[1:] {
+		entries = append(entries, AndroidMkEntry{
+			Class:      "ETC",
+			OutputFile: out,
+			ExtraEntries: map[string]string{
+				"LOCAL_MODULE": fmt.Sprintf("%s_%d", moduleName, i+1),
+			},
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,44 @@
+package genrule
+
+import "testing"
+
+func TestBuildAndroidMkEntries_SingleOutput(t *testing.T) {
+	entries, err := BuildAndroidMkEntries("gen_foo", "", []string{"out/foo.h"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].OutputFile != "out/foo.h" {
+		t.Errorf("got output %q", entries[0].OutputFile)
+	}
+	if entries[0].PhonyAlias != "" {
+		t.Errorf("expected no phony alias without a subName, got %q", entries[0].PhonyAlias)
+	}
+}
+
+func TestBuildAndroidMkEntries_MultiOutputWithPhonyAlias(t *testing.T) {
+	entries, err := BuildAndroidMkEntries("gen_foo", "_32", []string{"out/foo.cpp", "out/foo.h"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].PhonyAlias != "gen_foo_32" {
+		t.Errorf("got phony alias %q", entries[0].PhonyAlias)
+	}
+	if entries[1].OutputFile != "out/foo.h" {
+		t.Errorf("got extra output %q", entries[1].OutputFile)
+	}
+	if entries[1].ExtraEntries["LOCAL_MODULE"] != "gen_foo_1" {
+		t.Errorf("got extra module name %q", entries[1].ExtraEntries["LOCAL_MODULE"])
+	}
+}
+
+func TestBuildAndroidMkEntries_NoOutputsIsError(t *testing.T) {
+	if _, err := BuildAndroidMkEntries("gen_foo", "", nil); err == nil {
+		t.Errorf("expected an error for a genrule with no outputs")
+	}
+}
@@ -0,0 +1,31 @@
+package genrule
+
+import "fmt"
+
+// ArchSpecificProperty names one arch-tagged property block a user set on a module type that
+// isn't arch-mutated, so the value would otherwise be silently dropped.
+type ArchSpecificProperty struct {
+	PropertyPath string // e.g. "arch.arm64.srcs"
+	Arch         string
+}
+
+// ArchMutatedModuleTypes are the genrule-family module types that are actually arch-mutated;
+// plain genrule isn't in this set, so arch-specific blocks on it are always a mistake today.
+var ArchMutatedModuleTypes = map[string]bool{
+	"cc_genrule": true,
+}
+
+// ValidateNoDroppedArchProperties errors when a module of a non-arch-mutated type has arch-
+// specific values set on arch_variant-tagged properties (Srcs, Exclude_srcs, Out), which would
+// otherwise be silently ignored, guiding the user to cc_genrule or none at all.
+func ValidateNoDroppedArchProperties(moduleType string, archProps []ArchSpecificProperty) error {
+	if ArchMutatedModuleTypes[moduleType] || len(archProps) == 0 {
+		return nil
+	}
+
+	p := archProps[0]
+	return fmt.Errorf(
+		"%s: %q is set, but %s modules are not arch-mutated so this value would be silently "+
+			"dropped; use cc_genrule instead, or remove the arch-specific block",
+		moduleType, p.PropertyPath, moduleType)
+}
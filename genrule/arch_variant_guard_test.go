@@ -0,0 +1,23 @@
+package genrule
+
+import "testing"
+
+func TestValidateNoDroppedArchProperties_PlainGenruleWithArchSrcs(t *testing.T) {
+	archProps := []ArchSpecificProperty{{PropertyPath: "arch.arm64.srcs", Arch: "arm64"}}
+	if err := ValidateNoDroppedArchProperties("genrule", archProps); err == nil {
+		t.Errorf("expected an error for arch-specific srcs on a plain genrule")
+	}
+}
+
+func TestValidateNoDroppedArchProperties_CcGenruleAllowed(t *testing.T) {
+	archProps := []ArchSpecificProperty{{PropertyPath: "arch.arm64.srcs", Arch: "arm64"}}
+	if err := ValidateNoDroppedArchProperties("cc_genrule", archProps); err != nil {
+		t.Errorf("unexpected error for cc_genrule: %v", err)
+	}
+}
+
+func TestValidateNoDroppedArchProperties_NoArchPropsIsFine(t *testing.T) {
+	if err := ValidateNoDroppedArchProperties("genrule", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
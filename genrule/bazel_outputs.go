@@ -0,0 +1,64 @@
+package genrule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BazelGenruleResult is what generateBazelBuildActions gets back from a successful Bazel genrule
+// action: the output files under the Bazel output roots.
+type BazelGenruleResult struct {
+	OutputFiles []string
+	OutputRoots []string
+}
+
+// ResolveExportedIncludeDirs derives exportedIncludeDirs from the Bazel output roots (respecting
+// Export_include_dirs subpaths), instead of pointing at the now-empty Soong gen dir. Each export
+// dir is resolved against every output root, in root order, keeping only subpaths that are
+// actually present among outputFiles.
+func ResolveExportedIncludeDirs(result BazelGenruleResult, exportIncludeDirs []string) []string {
+	var dirs []string
+	for _, root := range result.OutputRoots {
+		for _, sub := range exportIncludeDirs {
+			dir := joinCleanPath(root, sub)
+			if dirHasAnyFile(dir, result.OutputFiles) {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+func joinCleanPath(root, sub string) string {
+	if sub == "" || sub == "." {
+		return root
+	}
+	return root + "/" + sub
+}
+
+func dirHasAnyFile(dir string, files []string) bool {
+	prefix := dir + "/"
+	for _, f := range files {
+		if strings.HasPrefix(f, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratedHeaderDepsFromBazel returns the Bazel output files a cc consumer must depend on as
+// generated headers, which for a Bazel-backed genrule is simply every output file (the Soong gen
+// dir contents are gone; the Bazel outputs are now the only source of truth).
+func GeneratedHeaderDepsFromBazel(result BazelGenruleResult) []string {
+	return append([]string(nil), result.OutputFiles...)
+}
+
+// ValidateBazelGenruleOutputs errors when a Bazel genrule action returned zero files, so the
+// handler can fall back to Soong with a warning instead of silently producing an empty
+// outputFiles list.
+func ValidateBazelGenruleOutputs(moduleName string, result BazelGenruleResult) error {
+	if len(result.OutputFiles) == 0 {
+		return fmt.Errorf("genrule %q: bazel action returned zero output files, falling back to soong", moduleName)
+	}
+	return nil
+}
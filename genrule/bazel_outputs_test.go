@@ -0,0 +1,47 @@
+package genrule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveExportedIncludeDirs_SubpathUnderOutputRoot(t *testing.T) {
+	result := BazelGenruleResult{
+		OutputFiles: []string{"bazel-out/foo/include/bar.h", "bazel-out/foo/src/bar.cpp"},
+		OutputRoots: []string{"bazel-out/foo"},
+	}
+	got := ResolveExportedIncludeDirs(result, []string{"include"})
+	want := []string{"bazel-out/foo/include"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolveExportedIncludeDirs_EmptySubpathIsOutputRoot(t *testing.T) {
+	result := BazelGenruleResult{
+		OutputFiles: []string{"bazel-out/foo/bar.h"},
+		OutputRoots: []string{"bazel-out/foo"},
+	}
+	got := ResolveExportedIncludeDirs(result, []string{"."})
+	want := []string{"bazel-out/foo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGeneratedHeaderDepsFromBazel(t *testing.T) {
+	result := BazelGenruleResult{OutputFiles: []string{"a.h", "b.h"}}
+	got := GeneratedHeaderDepsFromBazel(result)
+	if !reflect.DeepEqual(got, result.OutputFiles) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestValidateBazelGenruleOutputs_ZeroFilesErrors(t *testing.T) {
+	if err := ValidateBazelGenruleOutputs("gen_foo", BazelGenruleResult{}); err == nil {
+		t.Errorf("expected an error for zero output files")
+	}
+	if err := ValidateBazelGenruleOutputs("gen_foo", BazelGenruleResult{OutputFiles: []string{"a.h"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
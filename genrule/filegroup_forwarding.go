@@ -0,0 +1,54 @@
+package genrule
+
+// GeneratedSourceInfo is the provider data a genrule exposes to its consumers: the deps a
+// consumer must add as implicits, and the directories its non-compilable outputs live under so
+// cc consumers can find headers.
+type GeneratedSourceInfo struct {
+	GeneratedDeps       []string
+	GeneratedHeaderDirs []string
+}
+
+// FilegroupMember is the minimal view of one filegroup's source a caller needs to decide whether
+// the filegroup should forward a producer's GeneratedSourceInfo.
+type FilegroupMember struct {
+	ModuleName string
+	// GenruleInfo is the GeneratedSourceInfo of the genrule that produced this member, or nil if
+	// this member's source isn't a genrule output.
+	GenruleInfo *GeneratedSourceInfo
+}
+
+// ForwardedFilegroupInfo combines the GeneratedSourceInfo of every genrule-output member of a
+// filegroup into the value cc consumers should see whether they depend on the genrule directly
+// or through the wrapping filegroup. It returns (info, true) only when every member of the
+// filegroup is a genrule output — a filegroup mixing genrule outputs with plain source files has
+// nothing consistent to forward, so it returns (zero value, false).
+func ForwardedFilegroupInfo(members []FilegroupMember) (GeneratedSourceInfo, bool) {
+	if len(members) == 0 {
+		return GeneratedSourceInfo{}, false
+	}
+
+	var combined GeneratedSourceInfo
+	for _, m := range members {
+		if m.GenruleInfo == nil {
+			return GeneratedSourceInfo{}, false
+		}
+		combined.GeneratedDeps = append(combined.GeneratedDeps, m.GenruleInfo.GeneratedDeps...)
+		combined.GeneratedHeaderDirs = append(combined.GeneratedHeaderDirs, dedupeStrings(combined.GeneratedHeaderDirs, m.GenruleInfo.GeneratedHeaderDirs)...)
+	}
+	return combined, true
+}
+
+func dedupeStrings(existing, add []string) []string {
+	seen := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		seen[s] = true
+	}
+	var result []string
+	for _, s := range add {
+		if !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package genrule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForwardedFilegroupInfo_AllGenruleMembers(t *testing.T) {
+	members := []FilegroupMember{
+		{ModuleName: "gen_a", GenruleInfo: &GeneratedSourceInfo{GeneratedDeps: []string{"a.cpp"}, GeneratedHeaderDirs: []string{"gen/a"}}},
+		{ModuleName: "gen_b", GenruleInfo: &GeneratedSourceInfo{GeneratedDeps: []string{"b.cpp"}, GeneratedHeaderDirs: []string{"gen/a"}}},
+	}
+
+	info, ok := ForwardedFilegroupInfo(members)
+	if !ok {
+		t.Fatalf("expected forwarding to succeed when all members are genrule outputs")
+	}
+	if !reflect.DeepEqual(info.GeneratedDeps, []string{"a.cpp", "b.cpp"}) {
+		t.Errorf("got deps %v", info.GeneratedDeps)
+	}
+	if !reflect.DeepEqual(info.GeneratedHeaderDirs, []string{"gen/a"}) {
+		t.Errorf("expected deduped header dirs, got %v", info.GeneratedHeaderDirs)
+	}
+}
+
+func TestForwardedFilegroupInfo_MixedMembersDoesNotForward(t *testing.T) {
+	members := []FilegroupMember{
+		{ModuleName: "gen_a", GenruleInfo: &GeneratedSourceInfo{GeneratedDeps: []string{"a.cpp"}}},
+		{ModuleName: "plain.cpp", GenruleInfo: nil},
+	}
+
+	_, ok := ForwardedFilegroupInfo(members)
+	if ok {
+		t.Errorf("did not expect forwarding for a filegroup mixing genrule and plain sources")
+	}
+}
+
+func TestForwardedFilegroupInfo_ConsumerImplicitsMatchDirectAndViaFilegroup(t *testing.T) {
+	genruleInfo := GeneratedSourceInfo{GeneratedDeps: []string{"a.cpp"}, GeneratedHeaderDirs: []string{"gen/a"}}
+
+	direct := genruleInfo
+	viaFilegroup, ok := ForwardedFilegroupInfo([]FilegroupMember{{ModuleName: "gen_a", GenruleInfo: &genruleInfo}})
+	if !ok {
+		t.Fatalf("expected forwarding to succeed")
+	}
+	if !reflect.DeepEqual(direct.GeneratedDeps, viaFilegroup.GeneratedDeps) ||
+		!reflect.DeepEqual(direct.GeneratedHeaderDirs, viaFilegroup.GeneratedHeaderDirs) {
+		t.Errorf("expected consumer implicits to match whether referencing the genrule directly or via the filegroup")
+	}
+}
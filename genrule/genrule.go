@@ -0,0 +1,88 @@
+// Package genrule implements the module types for generic build-rule execution: running an
+// arbitrary command over a set of inputs to produce one or more declared outputs.
+package genrule
+
+import "fmt"
+
+// CaptureStdoutToProperties is the subset of a genrule module's properties that control
+// redirecting the command's stdout into one of its declared outputs instead of relying on shell
+// redirection inside cmd.
+type CaptureStdoutToProperties struct {
+	// Capture_stdout_to names one of the module's Out entries that should receive the command's
+	// stdout. When set, cmd must not itself redirect stdout with ">"; the rule wrapper captures
+	// stdout to a temp file and renames it into place after the command exits successfully, so a
+	// shell that swallows exit status on redirection failure (and paths containing spaces) can't
+	// silently produce a truncated or missing output.
+	Capture_stdout_to *string
+}
+
+// ValidateCaptureStdoutTo checks that Capture_stdout_to, if set, names one of outs and that cmd
+// doesn't also try to redirect stdout itself, returning a descriptive error otherwise.
+func ValidateCaptureStdoutTo(props CaptureStdoutToProperties, cmd string, outs []string) error {
+	if props.Capture_stdout_to == nil {
+		return nil
+	}
+	out := *props.Capture_stdout_to
+
+	found := false
+	for _, o := range outs {
+		if o == out {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("capture_stdout_to: %q is not one of the module's out entries", out)
+	}
+
+	if containsUnescapedRedirect(cmd) {
+		return fmt.Errorf("capture_stdout_to is set; cmd must not also redirect stdout with '>'")
+	}
+
+	return nil
+}
+
+// containsUnescapedRedirect reports whether cmd contains a shell stdout redirection, ignoring a
+// ">" that's escaped or inside single or double quotes (e.g. an arg like "--sep=>" shouldn't
+// trip this check).
+func containsUnescapedRedirect(cmd string) bool {
+	var inSingle, inDouble bool
+	for i := 0; i < len(cmd); i++ {
+		switch cmd[i] {
+		case '\\':
+			if !inSingle {
+				i++ // skip the escaped character
+			}
+		case '\'':
+			if !inDouble {
+				inSingle = !inSingle
+			}
+		case '"':
+			if !inSingle {
+				inDouble = !inDouble
+			}
+		case '>':
+			if !inSingle && !inDouble {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WrapCaptureStdoutTo rewraps cmd so that, when Capture_stdout_to is set, stdout is redirected
+// safely: written to a temp file alongside the real output and renamed into place only if cmd
+// exits zero, with "set -o pipefail" semantics so a failure upstream of a pipe still fails the
+// rule. outPath is the resolved path of the named out entry. If Capture_stdout_to is unset, cmd
+// is returned unchanged.
+func WrapCaptureStdoutTo(props CaptureStdoutToProperties, cmd string, outPath string) string {
+	if props.Capture_stdout_to == nil {
+		return cmd
+	}
+	tmp := outPath + ".tmp"
+	return fmt.Sprintf("set -o pipefail && (%s) > %s && mv %s %s", cmd, shellQuote(tmp), shellQuote(tmp), shellQuote(outPath))
+}
+
+func shellQuote(s string) string {
+	return "'" + s + "'"
+}
@@ -0,0 +1,72 @@
+package genrule
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestValidateCaptureStdoutTo_MissingOut(t *testing.T) {
+	props := CaptureStdoutToProperties{Capture_stdout_to: strPtr("missing.txt")}
+	err := ValidateCaptureStdoutTo(props, "tool $(in)", []string{"out.txt"})
+	if err == nil {
+		t.Fatalf("expected error for out entry that doesn't exist")
+	}
+}
+
+func TestValidateCaptureStdoutTo_CmdAlsoRedirects(t *testing.T) {
+	props := CaptureStdoutToProperties{Capture_stdout_to: strPtr("out.txt")}
+	err := ValidateCaptureStdoutTo(props, "tool $(in) > $(out)", []string{"out.txt"})
+	if err == nil {
+		t.Fatalf("expected error when cmd also redirects stdout")
+	}
+}
+
+func TestValidateCaptureStdoutTo_Valid(t *testing.T) {
+	props := CaptureStdoutToProperties{Capture_stdout_to: strPtr("out.txt")}
+	if err := ValidateCaptureStdoutTo(props, "tool $(in)", []string{"out.txt"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateCaptureStdoutTo_Unset(t *testing.T) {
+	if err := ValidateCaptureStdoutTo(CaptureStdoutToProperties{}, "tool $(in) > $(out)", []string{"out.txt"}); err != nil {
+		t.Errorf("unexpected error when capture_stdout_to is unset: %v", err)
+	}
+}
+
+func TestWrapCaptureStdoutTo_Success(t *testing.T) {
+	props := CaptureStdoutToProperties{Capture_stdout_to: strPtr("out.txt")}
+	outPath := filepath.Join(t.TempDir(), "out.txt")
+	wrapped := WrapCaptureStdoutTo(props, "echo hello", outPath)
+	if !strings.Contains(wrapped, "set -o pipefail") {
+		t.Errorf("expected pipefail semantics in wrapped command: %s", wrapped)
+	}
+
+	out, err := exec.Command("bash", "-c", wrapped).CombinedOutput()
+	if err != nil {
+		t.Fatalf("wrapped command failed: %v, output: %s", err, out)
+	}
+}
+
+func TestWrapCaptureStdoutTo_NonZeroExitPropagation(t *testing.T) {
+	props := CaptureStdoutToProperties{Capture_stdout_to: strPtr("out.txt")}
+	outPath := filepath.Join(t.TempDir(), "missing.txt")
+	wrapped := WrapCaptureStdoutTo(props, "false", outPath)
+
+	cmd := exec.Command("bash", "-c", wrapped)
+	if err := cmd.Run(); err == nil {
+		t.Fatalf("expected non-zero exit to propagate")
+	}
+}
+
+func TestValidateCaptureStdoutTo_CmdRedirectInsideQuotesAllowed(t *testing.T) {
+	props := CaptureStdoutToProperties{Capture_stdout_to: strPtr("out.txt")}
+	err := ValidateCaptureStdoutTo(props, `tool --sep='>' $(in)`, []string{"out.txt"})
+	if err != nil {
+		t.Errorf("unexpected error for a quoted '>' that isn't a real redirect: %v", err)
+	}
+}
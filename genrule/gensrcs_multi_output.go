@@ -0,0 +1,64 @@
+package genrule
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// GensrcsMultiOutputProperties extends a gensrcs module with support for producing more than one
+// output per input from a single command invocation, so a tool that emits e.g. a ".cpp" and a
+// ".h" per input only needs to run once.
+type GensrcsMultiOutputProperties struct {
+	// Output_extensions lists every extension the command produces per input, replacing the
+	// input's own extension, e.g. []string{".cpp", ".h"}. Mutually exclusive with the singular
+	// Output_extension.
+	Output_extensions []string
+}
+
+// compilableExtensions are the subset of output extensions consumed as GeneratedSourceFiles; the
+// rest are treated as headers, exposed through GeneratedHeaderDirs/GeneratedDeps.
+var compilableExtensions = map[string]bool{
+	".c":   true,
+	".cc":  true,
+	".cpp": true,
+	".cxx": true,
+	".S":   true,
+}
+
+// OutputsForInput returns the per-input output paths gensrcs produces for one input file, one
+// per entry in outputExtensions, with the input's own extension replaced by each.
+func OutputsForInput(input string, outputExtensions []string) []string {
+	base := strings.TrimSuffix(input, filepath.Ext(input))
+	outs := make([]string, len(outputExtensions))
+	for i, ext := range outputExtensions {
+		outs[i] = base + ext
+	}
+	return outs
+}
+
+// PartitionMultiOutputs splits outs (as produced by OutputsForInput across all inputs) into the
+// compilable outputs gensrcs should expose as GeneratedSourceFiles and the non-compilable ones
+// exposed as GeneratedHeaderDirs/GeneratedDeps.
+func PartitionMultiOutputs(outs []string) (sources, headers []string) {
+	for _, out := range outs {
+		if compilableExtensions[filepath.Ext(out)] {
+			sources = append(sources, out)
+		} else {
+			headers = append(headers, out)
+		}
+	}
+	return sources, headers
+}
+
+// ValidateOutputExtensions rejects configurations that set both the singular and plural
+// properties, and requires at least one extension when the plural property is used.
+func ValidateOutputExtensions(outputExtension *string, outputExtensions []string) error {
+	if outputExtension != nil && len(outputExtensions) > 0 {
+		return fmt.Errorf("output_extension and output_extensions are mutually exclusive")
+	}
+	if outputExtension == nil && len(outputExtensions) == 0 {
+		return fmt.Errorf("one of output_extension or output_extensions must be set")
+	}
+	return nil
+}
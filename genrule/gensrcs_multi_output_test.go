@@ -0,0 +1,39 @@
+package genrule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOutputsForInput(t *testing.T) {
+	got := OutputsForInput("foo.idl", []string{".cpp", ".h"})
+	want := []string{"foo.cpp", "foo.h"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPartitionMultiOutputs_CppHeaderPair(t *testing.T) {
+	outs := OutputsForInput("foo.idl", []string{".cpp", ".h"})
+	sources, headers := PartitionMultiOutputs(outs)
+
+	if !reflect.DeepEqual(sources, []string{"foo.cpp"}) {
+		t.Errorf("got sources %v, want [foo.cpp]", sources)
+	}
+	if !reflect.DeepEqual(headers, []string{"foo.h"}) {
+		t.Errorf("got headers %v, want [foo.h]", headers)
+	}
+}
+
+func TestValidateOutputExtensions(t *testing.T) {
+	ext := ".cpp"
+	if err := ValidateOutputExtensions(&ext, []string{".h"}); err == nil {
+		t.Errorf("expected error when both output_extension and output_extensions are set")
+	}
+	if err := ValidateOutputExtensions(nil, nil); err == nil {
+		t.Errorf("expected error when neither is set")
+	}
+	if err := ValidateOutputExtensions(nil, []string{".cpp", ".h"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package genrule
+
+import "fmt"
+
+// HostOS identifies the build-machine OS a genrule's tools run on.
+type HostOS string
+
+const (
+	Linux   HostOS = "linux"
+	Darwin  HostOS = "darwin"
+	Windows HostOS = "windows"
+)
+
+// HostOSToolFiles lets a genrule module supply different tool_files per host OS, e.g. a .exe
+// wrapper for windows next to a shell script for linux/darwin, mirroring the target: { <os>: {} }
+// property-selection style used elsewhere in cc/genrule.
+type HostOSToolFiles struct {
+	Linux   []string
+	Darwin  []string
+	Windows []string
+}
+
+func (h HostOSToolFiles) forOS(os HostOS) []string {
+	switch os {
+	case Linux:
+		return h.Linux
+	case Darwin:
+		return h.Darwin
+	case Windows:
+		return h.Windows
+	default:
+		return nil
+	}
+}
+
+// ResolveToolFilesForHostOS returns the base tool_files plus whichever per-OS tool_files entry
+// matches buildOS, so a genrule can supply the same base tools everywhere and vary only what
+// differs per host.
+func ResolveToolFilesForHostOS(baseToolFiles []string, perOS HostOSToolFiles, buildOS HostOS) []string {
+	resolved := append([]string(nil), baseToolFiles...)
+	resolved = append(resolved, perOS.forOS(buildOS)...)
+	return resolved
+}
+
+// ToolDepsError reports that a genrule's tools dependency is disabled for the variation the
+// genrule is being built for, naming both the requested variation and the ones the tool module
+// actually provides, instead of the generic blueprint "depends on disabled module" message.
+type ToolDepsError struct {
+	ToolModule        string
+	RequestedVariant  string
+	AvailableVariants []string
+}
+
+func (e *ToolDepsError) Error() string {
+	return fmt.Sprintf(
+		"tool %q is disabled for host OS variation %q (available: %v); "+
+			"add it to the matching target.<os>.tool_files, or enable %q for %q",
+		e.ToolModule, e.RequestedVariant, e.AvailableVariants, e.ToolModule, e.RequestedVariant)
+}
+
+// ValidateToolAvailableForHostOS returns a *ToolDepsError when toolModule isn't enabled for
+// requestedVariant, for the toolDepsMutator to surface instead of failing deep in blueprint.
+func ValidateToolAvailableForHostOS(toolModule, requestedVariant string, availableVariants []string) error {
+	for _, v := range availableVariants {
+		if v == requestedVariant {
+			return nil
+		}
+	}
+	return &ToolDepsError{
+		ToolModule:        toolModule,
+		RequestedVariant:  requestedVariant,
+		AvailableVariants: availableVariants,
+	}
+}
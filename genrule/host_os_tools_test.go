@@ -0,0 +1,41 @@
+package genrule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveToolFilesForHostOS_DarwinLinuxSplit(t *testing.T) {
+	perOS := HostOSToolFiles{
+		Linux:  []string{"tool.sh"},
+		Darwin: []string{"tool_mac.sh"},
+	}
+
+	linux := ResolveToolFilesForHostOS([]string{"common.sh"}, perOS, Linux)
+	if !reflect.DeepEqual(linux, []string{"common.sh", "tool.sh"}) {
+		t.Errorf("got %v", linux)
+	}
+
+	darwin := ResolveToolFilesForHostOS([]string{"common.sh"}, perOS, Darwin)
+	if !reflect.DeepEqual(darwin, []string{"common.sh", "tool_mac.sh"}) {
+		t.Errorf("got %v", darwin)
+	}
+}
+
+func TestValidateToolAvailableForHostOS(t *testing.T) {
+	if err := ValidateToolAvailableForHostOS("mytool", "linux", []string{"linux", "darwin"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := ValidateToolAvailableForHostOS("mytool", "windows", []string{"linux", "darwin"})
+	if err == nil {
+		t.Fatalf("expected an error for a windows-only genrule depending on a linux/darwin-only tool")
+	}
+	toolErr, ok := err.(*ToolDepsError)
+	if !ok {
+		t.Fatalf("expected a *ToolDepsError, got %T", err)
+	}
+	if toolErr.RequestedVariant != "windows" {
+		t.Errorf("got requested variant %q", toolErr.RequestedVariant)
+	}
+}
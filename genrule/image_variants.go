@@ -0,0 +1,60 @@
+package genrule
+
+import "fmt"
+
+// ImageVariant identifies which image partition a genrule variant generates its output for.
+// Plain genrule modules previously wired noopImageInterface and only ever had "core"; opting in
+// with Vendor_available/Recovery_available creates the additional variants below.
+type ImageVariant string
+
+const (
+	CoreImageVariant     ImageVariant = "core"
+	VendorImageVariant   ImageVariant = "vendor"
+	RecoveryImageVariant ImageVariant = "recovery"
+)
+
+// ImageVariantProperties opts a genrule module into per-image variants, for generators whose
+// output legitimately differs between core, vendor and recovery (e.g. sepolicy fragments compiled
+// from different inputs per image).
+type ImageVariantProperties struct {
+	Vendor_available   *bool
+	Recovery_available *bool
+}
+
+func boolValue(b *bool) bool { return b != nil && *b }
+
+// ImageVariantsFor returns every image variant a genrule module with the given properties should
+// be split into. A module that opts into none of them stays single-variant (core only), matching
+// today's noopImageInterface behavior.
+func ImageVariantsFor(props ImageVariantProperties) []ImageVariant {
+	variants := []ImageVariant{CoreImageVariant}
+	if boolValue(props.Vendor_available) {
+		variants = append(variants, VendorImageVariant)
+	}
+	if boolValue(props.Recovery_available) {
+		variants = append(variants, RecoveryImageVariant)
+	}
+	return variants
+}
+
+// GenDirForVariant returns the per-variant gen directory a genrule variant's $(genDir) and
+// outputs should live under, keeping vendor/recovery variants from colliding with each other or
+// with core in the same module's output namespace.
+func GenDirForVariant(baseGenDir string, variant ImageVariant) string {
+	if variant == CoreImageVariant {
+		return baseGenDir
+	}
+	return fmt.Sprintf("%s/%s", baseGenDir, variant)
+}
+
+// RequestedImageVariantForConsumer returns the image variant a dependent cc module (itself built
+// for requesterVariant) should depend on: the genrule's matching variant if it has one, or core as
+// the fallback for genrules that never opted into image variants.
+func RequestedImageVariantForConsumer(requesterVariant ImageVariant, available []ImageVariant) ImageVariant {
+	for _, v := range available {
+		if v == requesterVariant {
+			return v
+		}
+	}
+	return CoreImageVariant
+}
@@ -0,0 +1,40 @@
+package genrule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestImageVariantsFor(t *testing.T) {
+	if got := ImageVariantsFor(ImageVariantProperties{}); !reflect.DeepEqual(got, []ImageVariant{CoreImageVariant}) {
+		t.Errorf("got %v, want core-only", got)
+	}
+
+	got := ImageVariantsFor(ImageVariantProperties{Vendor_available: boolPtr(true), Recovery_available: boolPtr(true)})
+	want := []ImageVariant{CoreImageVariant, VendorImageVariant, RecoveryImageVariant}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGenDirForVariant(t *testing.T) {
+	if got := GenDirForVariant("out/soong/.intermediates/foo", CoreImageVariant); got != "out/soong/.intermediates/foo" {
+		t.Errorf("got %q", got)
+	}
+	if got := GenDirForVariant("out/soong/.intermediates/foo", VendorImageVariant); got != "out/soong/.intermediates/foo/vendor" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRequestedImageVariantForConsumer(t *testing.T) {
+	available := []ImageVariant{CoreImageVariant, VendorImageVariant}
+
+	if got := RequestedImageVariantForConsumer(VendorImageVariant, available); got != VendorImageVariant {
+		t.Errorf("expected a vendor cc_library to see the vendor genrule variant, got %v", got)
+	}
+	if got := RequestedImageVariantForConsumer(RecoveryImageVariant, available); got != CoreImageVariant {
+		t.Errorf("expected fallback to core when the genrule has no recovery variant, got %v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package genrule
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxInputAgeProperties opts a genrule into a freshness check against the newest of its declared
+// srcs, for generators that regenerate code from a periodically refreshed prebuilt database whose
+// staleness would otherwise rot silently.
+type MaxInputAgeProperties struct {
+	// Max_input_age_days fails the build when the newest src's sidecar timestamp is older than
+	// this many days.
+	Max_input_age_days *int64
+}
+
+func (p *MaxInputAgeProperties) enabled() bool {
+	return p.Max_input_age_days != nil
+}
+
+// ParseTimestampFile parses a sidecar ".timestamp" file's content as an RFC 3339 / ISO 8601 date.
+func ParseTimestampFile(content string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", content)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp file content %q: want ISO 8601 date (YYYY-MM-DD): %w", content, err)
+	}
+	return t, nil
+}
+
+// CheckInputFreshness validates that newestTimestamp is within Max_input_age_days of now,
+// returning an error naming the age and the limit when it isn't. skipCheck (wired from an env var
+// for local builds) bypasses the check entirely.
+func CheckInputFreshness(props MaxInputAgeProperties, newestTimestamp, now time.Time, skipCheck bool) error {
+	if !props.enabled() || skipCheck {
+		return nil
+	}
+
+	age := now.Sub(newestTimestamp)
+	limit := time.Duration(*props.Max_input_age_days) * 24 * time.Hour
+	if age > limit {
+		return fmt.Errorf(
+			"genrule input is %.1f days old, which exceeds max_input_age_days (%d); "+
+				"refresh the source database, or set SOONG_SKIP_INPUT_AGE_CHECK=true for a local build",
+			age.Hours()/24, *props.Max_input_age_days)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package genrule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimestampFile(t *testing.T) {
+	got, err := ParseTimestampFile("2024-01-15")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := ParseTimestampFile("not-a-date"); err == nil {
+		t.Errorf("expected an error for a malformed timestamp")
+	}
+}
+
+func TestCheckInputFreshness_FreshAndStale(t *testing.T) {
+	maxAge := int64(7)
+	props := MaxInputAgeProperties{Max_input_age_days: &maxAge}
+	now := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	fresh := now.Add(-3 * 24 * time.Hour)
+	if err := CheckInputFreshness(props, fresh, now, false); err != nil {
+		t.Errorf("unexpected error for a fresh input: %v", err)
+	}
+
+	stale := now.Add(-10 * 24 * time.Hour)
+	if err := CheckInputFreshness(props, stale, now, false); err == nil {
+		t.Errorf("expected an error for a stale input")
+	}
+}
+
+func TestCheckInputFreshness_SkippedViaFlag(t *testing.T) {
+	maxAge := int64(1)
+	props := MaxInputAgeProperties{Max_input_age_days: &maxAge}
+	now := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	stale := now.Add(-30 * 24 * time.Hour)
+
+	if err := CheckInputFreshness(props, stale, now, true); err != nil {
+		t.Errorf("expected the check to be skipped, got %v", err)
+	}
+}
+
+func TestCheckInputFreshness_DisabledByDefault(t *testing.T) {
+	now := time.Now()
+	if err := CheckInputFreshness(MaxInputAgeProperties{}, now.Add(-1000*24*time.Hour), now, false); err != nil {
+		t.Errorf("expected no error when max_input_age_days is unset, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package genrule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Namespace is the minimal view of a Soong namespace needed to resolve a ":module" source
+// reference: its path and the namespaces it imports, per the namespace config.
+type Namespace struct {
+	Path    string
+	Imports []string
+}
+
+// ResolveModuleInNamespaces searches currentNamespace and, per its Imports, every namespace it
+// imports directly (an imported namespace's own imports are not searched — imports are not
+// transitive per Soong namespace semantics) for moduleName, using moduleExists to check
+// whether a given namespace defines it. It returns the namespace path that defines moduleName,
+// or an error listing every namespace searched and a hint about the `imports` property when none
+// do.
+func ResolveModuleInNamespaces(moduleName string, currentNamespace Namespace, namespaces map[string]Namespace, moduleExists func(namespacePath, moduleName string) bool) (string, error) {
+	searched := []string{currentNamespace.Path}
+	if moduleExists(currentNamespace.Path, moduleName) {
+		return currentNamespace.Path, nil
+	}
+
+	for _, importPath := range currentNamespace.Imports {
+		searched = append(searched, importPath)
+		if moduleExists(importPath, moduleName) {
+			return importPath, nil
+		}
+	}
+
+	return "", fmt.Errorf("srcs: module %q not found; searched namespaces %s (add it to the producing module's namespace's `imports` if it lives elsewhere)",
+		moduleName, strings.Join(searched, ", "))
+}
@@ -0,0 +1,38 @@
+package genrule
+
+import "testing"
+
+func TestResolveModuleInNamespaces_FoundViaImport(t *testing.T) {
+	current := Namespace{Path: "vendor/a", Imports: []string{"vendor/b"}}
+	exists := func(ns, name string) bool { return ns == "vendor/b" && name == "tool_output" }
+
+	ns, err := ResolveModuleInNamespaces("tool_output", current, nil, exists)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ns != "vendor/b" {
+		t.Errorf("got %q, want vendor/b", ns)
+	}
+}
+
+func TestResolveModuleInNamespaces_NotFoundWithoutImport(t *testing.T) {
+	current := Namespace{Path: "vendor/a"} // no imports
+	exists := func(ns, name string) bool { return ns == "vendor/b" && name == "tool_output" }
+
+	_, err := ResolveModuleInNamespaces("tool_output", current, nil, exists)
+	if err == nil {
+		t.Fatalf("expected an error when the producing namespace isn't imported")
+	}
+	if !contains(err.Error(), "imports") {
+		t.Errorf("expected error to hint about `imports`, got: %v", err)
+	}
+}
+
+func contains(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package genrule
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// OutputsAreHeadersProperties holds the `outputs_are_headers` genrule property.
+type OutputsAreHeadersProperties struct {
+	Outputs_are_headers *bool
+}
+
+// OutputsAreHeaders reports whether the module declared all its outputs to be headers only.
+func OutputsAreHeaders(props OutputsAreHeadersProperties) bool {
+	return props.Outputs_are_headers != nil && *props.Outputs_are_headers
+}
+
+var headerLikeExts = map[string]bool{
+	".h": true, ".hh": true, ".hpp": true, ".inc": true,
+}
+
+// ValidateOutputsAreHeaders errors if outputs_are_headers is set but any out entry doesn't look
+// like a header, since that almost certainly means the property was set on the wrong genrule.
+func ValidateOutputsAreHeaders(props OutputsAreHeadersProperties, outs []string) error {
+	if !OutputsAreHeaders(props) {
+		return nil
+	}
+	for _, out := range outs {
+		if !headerLikeExts[filepath.Ext(out)] {
+			return fmt.Errorf("outputs_are_headers: %q does not look like a header", out)
+		}
+	}
+	return nil
+}
+
+// GeneratedSourceFilesForConsumer returns the GeneratedSourceFiles a consumer listing this module
+// in generated_sources would see: empty, when outputs_are_headers is set, so a misuse (listing a
+// header-only genrule in generated_sources) produces no sources to silently compile or ignore
+// rather than the clear error the caller should raise instead.
+func GeneratedSourceFilesForConsumer(props OutputsAreHeadersProperties, outs []string) []string {
+	if OutputsAreHeaders(props) {
+		return nil
+	}
+	return outs
+}
+
+// GeneratedMisuseError reports that a header-only genrule was listed in generated_sources instead
+// of generated_headers.
+type GeneratedMisuseError struct {
+	ModuleName string
+}
+
+func (e *GeneratedMisuseError) Error() string {
+	return fmt.Sprintf("%s: outputs_are_headers is set, so it must be listed in generated_headers, not generated_sources", e.ModuleName)
+}
+
+// ValidateGeneratedSourcesUsage errors with GeneratedMisuseError if a header-only genrule was
+// listed in a consumer's generated_sources.
+func ValidateGeneratedSourcesUsage(props OutputsAreHeadersProperties, moduleName string, listedInGeneratedSources bool) error {
+	if OutputsAreHeaders(props) && listedInGeneratedSources {
+		return &GeneratedMisuseError{ModuleName: moduleName}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package genrule
+
+import "testing"
+
+func boolPtrOutputsHeaders(b bool) *bool { return &b }
+
+func TestValidateOutputsAreHeaders_RejectsNonHeaderOut(t *testing.T) {
+	props := OutputsAreHeadersProperties{Outputs_are_headers: boolPtrOutputsHeaders(true)}
+	if err := ValidateOutputsAreHeaders(props, []string{"foo.cpp"}); err == nil {
+		t.Errorf("expected an error for a non-header out")
+	}
+}
+
+func TestValidateOutputsAreHeaders_AllowsHeaders(t *testing.T) {
+	props := OutputsAreHeadersProperties{Outputs_are_headers: boolPtrOutputsHeaders(true)}
+	if err := ValidateOutputsAreHeaders(props, []string{"foo.h", "bar.inc"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGeneratedSourceFilesForConsumer_EmptyWhenHeadersOnly(t *testing.T) {
+	props := OutputsAreHeadersProperties{Outputs_are_headers: boolPtrOutputsHeaders(true)}
+	if got := GeneratedSourceFilesForConsumer(props, []string{"foo.h"}); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
+
+func TestValidateGeneratedSourcesUsage_MisuseError(t *testing.T) {
+	props := OutputsAreHeadersProperties{Outputs_are_headers: boolPtrOutputsHeaders(true)}
+	err := ValidateGeneratedSourcesUsage(props, "gen_foo", true)
+	if err == nil {
+		t.Fatalf("expected a misuse error")
+	}
+	if _, ok := err.(*GeneratedMisuseError); !ok {
+		t.Errorf("got error of type %T, want *GeneratedMisuseError", err)
+	}
+}
+
+func TestValidateGeneratedSourcesUsage_CorrectUsageOk(t *testing.T) {
+	props := OutputsAreHeadersProperties{Outputs_are_headers: boolPtrOutputsHeaders(true)}
+	if err := ValidateGeneratedSourcesUsage(props, "gen_foo", false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package genrule
+
+import "sort"
+
+// SortedPathExpansion returns a copy of paths sorted lexically, for use when expanding
+// multi-path genrule variables (such as $(in) or a module reference that resolves to several
+// files) into rawCommands: glob and multi-module results otherwise arrive in whatever order the
+// underlying filesystem or dependency walk produced them, which makes the expanded command
+// (and therefore the rule's build ID) nondeterministic across otherwise-identical builds.
+func SortedPathExpansion(paths []string) []string {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// PreserveSrcOrderProperties holds the opt-out for modules whose command depends on the
+// declaration order of srcs (for example a tool that treats its first input specially), which
+// SortedPathExpansion would otherwise break.
+type PreserveSrcOrderProperties struct {
+	// Preserve_src_order disables sorting of $(in)/$(locations) expansions for this module,
+	// keeping srcs in their declared order at the cost of the determinism SortedPathExpansion
+	// otherwise provides across incremental vs clean builds.
+	Preserve_src_order *bool
+}
+
+func (p *PreserveSrcOrderProperties) preserveOrder() bool {
+	return p.Preserve_src_order != nil && *p.Preserve_src_order
+}
+
+// ExpandPathsInOrder returns paths sorted for deterministic rawCommands, unless props opts out
+// via preserve_src_order, in which case paths is returned unchanged.
+func ExpandPathsInOrder(paths []string, props PreserveSrcOrderProperties) []string {
+	if props.preserveOrder() {
+		return paths
+	}
+	return SortedPathExpansion(paths)
+}
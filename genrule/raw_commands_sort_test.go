@@ -0,0 +1,39 @@
+package genrule
+
+import (
+	"reflect"
+	"testing"
+)
+
+func boolPtrGenrule(b bool) *bool { return &b }
+
+func TestSortedPathExpansion(t *testing.T) {
+	in := []string{"c.txt", "a.txt", "b.txt"}
+	got := SortedPathExpansion(in)
+	want := []string{"a.txt", "b.txt", "c.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(in, []string{"c.txt", "a.txt", "b.txt"}) {
+		t.Errorf("expected SortedPathExpansion to not mutate its input, got %v", in)
+	}
+}
+
+func TestExpandPathsInOrder_PermutedDeclarationOrderIsDeterministic(t *testing.T) {
+	order1 := []string{"b.txt", "a.txt", "c.txt"}
+	order2 := []string{"c.txt", "b.txt", "a.txt"}
+
+	got1 := ExpandPathsInOrder(order1, PreserveSrcOrderProperties{})
+	got2 := ExpandPathsInOrder(order2, PreserveSrcOrderProperties{})
+	if !reflect.DeepEqual(got1, got2) {
+		t.Errorf("expected permuted src declaration order to produce identical expansions, got %v vs %v", got1, got2)
+	}
+}
+
+func TestExpandPathsInOrder_PreserveSrcOrderOptOut(t *testing.T) {
+	in := []string{"b.txt", "a.txt"}
+	got := ExpandPathsInOrder(in, PreserveSrcOrderProperties{Preserve_src_order: boolPtrGenrule(true)})
+	if !reflect.DeepEqual(got, in) {
+		t.Errorf("expected preserve_src_order to keep declaration order, got %v", got)
+	}
+}
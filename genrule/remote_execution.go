@@ -0,0 +1,38 @@
+package genrule
+
+import "fmt"
+
+// RemoteExecutionProperties holds the RuleBuilder remote-execution annotation properties.
+type RemoteExecutionProperties struct {
+	// Remoteable opts the generated rule into remote execution; nil means the default (current
+	// behavior, decided elsewhere by policy).
+	Remoteable *bool
+	// No_cache disables the remote cache for this rule's action, e.g. because the command is
+	// nondeterministic or depends on unhashed state.
+	No_cache *bool
+}
+
+// RemoteExecutionAnnotations are the RuleBuilder-level flags to apply to the generated rule.
+type RemoteExecutionAnnotations struct {
+	Remoteable bool
+	NoCache    bool
+}
+
+// ResolveRemoteExecutionAnnotations returns the RuleBuilder annotations for props, defaulting
+// both to false (today's behavior) when unset.
+func ResolveRemoteExecutionAnnotations(props RemoteExecutionProperties) RemoteExecutionAnnotations {
+	return RemoteExecutionAnnotations{
+		Remoteable: props.Remoteable != nil && *props.Remoteable,
+		NoCache:    props.No_cache != nil && *props.No_cache,
+	}
+}
+
+// ValidateRemoteExecutionProperties errors on combinations that don't make sense together:
+// no_cache without remoteable has no effect on a local-only action's semantics and likely
+// indicates the user meant to set remoteable too.
+func ValidateRemoteExecutionProperties(props RemoteExecutionProperties) error {
+	if props.No_cache != nil && *props.No_cache && (props.Remoteable == nil || !*props.Remoteable) {
+		return fmt.Errorf("no_cache requires remoteable: true; a local-only rule has no remote cache to disable")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package genrule
+
+import "testing"
+
+func boolPtrRemote(b bool) *bool { return &b }
+
+func TestResolveRemoteExecutionAnnotations_Defaults(t *testing.T) {
+	got := ResolveRemoteExecutionAnnotations(RemoteExecutionProperties{})
+	if got.Remoteable || got.NoCache {
+		t.Errorf("expected both false by default, got %+v", got)
+	}
+}
+
+func TestResolveRemoteExecutionAnnotations_Set(t *testing.T) {
+	props := RemoteExecutionProperties{Remoteable: boolPtrRemote(true), No_cache: boolPtrRemote(true)}
+	got := ResolveRemoteExecutionAnnotations(props)
+	if !got.Remoteable || !got.NoCache {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestValidateRemoteExecutionProperties_NoCacheRequiresRemoteable(t *testing.T) {
+	props := RemoteExecutionProperties{No_cache: boolPtrRemote(true)}
+	if err := ValidateRemoteExecutionProperties(props); err == nil {
+		t.Errorf("expected an error for no_cache without remoteable")
+	}
+}
+
+func TestValidateRemoteExecutionProperties_ValidCombination(t *testing.T) {
+	props := RemoteExecutionProperties{Remoteable: boolPtrRemote(true), No_cache: boolPtrRemote(true)}
+	if err := ValidateRemoteExecutionProperties(props); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package genrule
+
+import "fmt"
+
+// maxDescriptionLen keeps the rule description reasonably short even for modules in deeply
+// nested directories or with long first-input names.
+const maxDescriptionLen = 80
+
+// RuleDescription builds the ninja "description" for a genrule's build rule, including the
+// module directory so a slow or failing build log line can be attributed to its Android.bp
+// without cross-referencing ninja files.
+func RuleDescription(moduleDir, moduleName string) string {
+	return truncateDescription(fmt.Sprintf("generate %s (%s)", moduleName, moduleDir))
+}
+
+// GensrcsShardDescription builds the ninja description for one shard of a gensrcs rule, also
+// naming the shard's first input file so per-shard log lines are distinguishable.
+func GensrcsShardDescription(moduleDir, moduleName string, shardInputs []string) string {
+	first := ""
+	if len(shardInputs) > 0 {
+		first = shardInputs[0]
+	}
+	return truncateDescription(fmt.Sprintf("generate %s (%s) from %s", moduleName, moduleDir, first))
+}
+
+// SboxErrorPrefix prefixes an sbox error with the module name, so a failure inside a dozen
+// identically-named generators can be attributed to the right Android.bp.
+func SboxErrorPrefix(moduleName string) string {
+	return fmt.Sprintf("%s: ", moduleName)
+}
+
+func truncateDescription(s string) string {
+	if len(s) <= maxDescriptionLen {
+		return s
+	}
+	return s[:maxDescriptionLen-3] + "..."
+}
@@ -0,0 +1,33 @@
+package genrule
+
+import "testing"
+
+func TestRuleDescription_IncludesModuleDir(t *testing.T) {
+	got := RuleDescription("device/foo/bar", "gen_baz")
+	want := "generate gen_baz (device/foo/bar)"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGensrcsShardDescription_IncludesFirstInput(t *testing.T) {
+	got := GensrcsShardDescription("device/foo", "gen_idl", []string{"a.proto", "b.proto"})
+	want := "generate gen_idl (device/foo) from a.proto"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRuleDescription_Truncated(t *testing.T) {
+	longDir := "device/some/very/deeply/nested/directory/structure/that/is/quite/long/indeed"
+	got := RuleDescription(longDir, "gen_baz")
+	if len(got) > maxDescriptionLen {
+		t.Errorf("expected description to be truncated to %d chars, got %d: %q", maxDescriptionLen, len(got), got)
+	}
+}
+
+func TestSboxErrorPrefix(t *testing.T) {
+	if got := SboxErrorPrefix("gen_baz"); got != "gen_baz: " {
+		t.Errorf("got %q", got)
+	}
+}
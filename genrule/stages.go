@@ -0,0 +1,75 @@
+package genrule
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Stage is one step of a multi-stage genrule pipeline: its own cmd and out entries, with cmd
+// allowed to reference earlier stages' outputs via "$(stageN_out)" labels instead of listing the
+// intermediate file in both out and srcs (which genrule rejects as a self-reference).
+type Stage struct {
+	Cmd string
+	Out []string
+}
+
+var stageLabelRe = regexp.MustCompile(`\$\(stage(\d+)_out\)`)
+
+// ResolveStageLabels expands every "$(stageN_out)" label in stages[index].Cmd into the
+// space-joined outputs of stages[N-1] (stages are 1-indexed in the label to match how users refer
+// to "stage 1", "stage 2", ...). It errors on a forward or self reference, and on a reference to a
+// stage number that doesn't exist.
+func ResolveStageLabels(stages []Stage, index int) (string, error) {
+	cmd := stages[index].Cmd
+
+	var resolveErr error
+	resolved := stageLabelRe.ReplaceAllStringFunc(cmd, func(label string) string {
+		m := stageLabelRe.FindStringSubmatch(label)
+		n := 0
+		for _, c := range m[1] {
+			n = n*10 + int(c-'0')
+		}
+		refIndex := n - 1
+		if refIndex < 0 || refIndex >= len(stages) {
+			resolveErr = fmt.Errorf("stage %d: %q references stage %d, which doesn't exist", index+1, label, n)
+			return label
+		}
+		if refIndex >= index {
+			resolveErr = fmt.Errorf("stage %d: %q references a later or the same stage; stages may only reference earlier stages", index+1, label)
+			return label
+		}
+		return strings.Join(stages[refIndex].Out, " ")
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return resolved, nil
+}
+
+// AllStageOutputs returns the union of every stage's declared outputs, the value the whole
+// pipeline's single sbox invocation must declare as its outputs.
+func AllStageOutputs(stages []Stage) []string {
+	var outs []string
+	for _, s := range stages {
+		outs = append(outs, s.Out...)
+	}
+	return outs
+}
+
+// ValidateStages checks that no stage is empty and that cmd-referenced stage labels are
+// resolvable, without actually resolving them (ResolveStageLabels does the resolution itself).
+func ValidateStages(stages []Stage) error {
+	if len(stages) == 0 {
+		return fmt.Errorf("stages: at least one stage is required")
+	}
+	for i, s := range stages {
+		if s.Cmd == "" {
+			return fmt.Errorf("stages[%d]: cmd is required", i)
+		}
+		if _, err := ResolveStageLabels(stages, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package genrule
+
+import "testing"
+
+func TestResolveStageLabels_TwoStagePipeline(t *testing.T) {
+	stages := []Stage{
+		{Cmd: "$(location gen) $(in) > $(out)", Out: []string{"intermediate.ir"}},
+		{Cmd: "$(location compile) $(stage1_out) -o $(out)", Out: []string{"final.bin"}},
+	}
+
+	resolved, err := ResolveStageLabels(stages, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "$(location compile) intermediate.ir -o $(out)"
+	if resolved != want {
+		t.Errorf("got %q, want %q", resolved, want)
+	}
+}
+
+func TestResolveStageLabels_ForwardReferenceRejected(t *testing.T) {
+	stages := []Stage{
+		{Cmd: "tool $(stage2_out)", Out: []string{"a.out"}},
+		{Cmd: "tool2", Out: []string{"b.out"}},
+	}
+	if _, err := ResolveStageLabels(stages, 0); err == nil {
+		t.Errorf("expected an error for a forward reference")
+	}
+}
+
+func TestResolveStageLabels_UnknownStageRejected(t *testing.T) {
+	stages := []Stage{
+		{Cmd: "tool", Out: []string{"a.out"}},
+		{Cmd: "tool $(stage5_out)", Out: []string{"b.out"}},
+	}
+	if _, err := ResolveStageLabels(stages, 1); err == nil {
+		t.Errorf("expected an error for a reference to a nonexistent stage")
+	}
+}
+
+func TestAllStageOutputs(t *testing.T) {
+	stages := []Stage{
+		{Out: []string{"a.out"}},
+		{Out: []string{"b.out", "c.out"}},
+	}
+	got := AllStageOutputs(stages)
+	want := []string{"a.out", "b.out", "c.out"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestValidateStages(t *testing.T) {
+	if err := ValidateStages(nil); err == nil {
+		t.Errorf("expected an error for zero stages")
+	}
+	if err := ValidateStages([]Stage{{Cmd: "", Out: []string{"a"}}}); err == nil {
+		t.Errorf("expected an error for an empty cmd")
+	}
+	valid := []Stage{
+		{Cmd: "tool $(in) > $(out)", Out: []string{"a.ir"}},
+		{Cmd: "tool2 $(stage1_out) > $(out)", Out: []string{"b.out"}},
+	}
+	if err := ValidateStages(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
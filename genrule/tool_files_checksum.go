@@ -0,0 +1,48 @@
+package genrule
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// ToolFilesChecksumProperties holds the `tool_files_sha256` property: a map from a tool_files
+// path to the expected hex-encoded sha256 digest of its contents.
+type ToolFilesChecksumProperties struct {
+	Tool_files_sha256 map[string]string
+}
+
+// ChecksumMismatchError reports a tool_files entry whose pinned digest didn't match.
+type ChecksumMismatchError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("tool_files_sha256: %s: expected %s, got %s", e.Path, e.Expected, e.Actual)
+}
+
+// Sha256Hex returns the hex-encoded sha256 digest of content.
+func Sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyToolFilesChecksums checks every path named in props.Tool_files_sha256 against the
+// provided contents (path -> file bytes, populated by the validation rule's caller for every
+// tool_files entry). It errors on the first mismatch or missing entry; tool_files paths not
+// listed in the property are unaffected and not checked.
+func VerifyToolFilesChecksums(props ToolFilesChecksumProperties, contents map[string][]byte) error {
+	for path, expected := range props.Tool_files_sha256 {
+		content, ok := contents[path]
+		if !ok {
+			return fmt.Errorf("tool_files_sha256: %q is not in tool_files", path)
+		}
+		actual := Sha256Hex(content)
+		if actual != expected {
+			return &ChecksumMismatchError{Path: path, Expected: expected, Actual: actual}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package genrule
+
+import "testing"
+
+func TestVerifyToolFilesChecksums_Match(t *testing.T) {
+	content := []byte("tool binary contents")
+	props := ToolFilesChecksumProperties{Tool_files_sha256: map[string]string{"tool.bin": Sha256Hex(content)}}
+	contents := map[string][]byte{"tool.bin": content}
+	if err := VerifyToolFilesChecksums(props, contents); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyToolFilesChecksums_Mismatch(t *testing.T) {
+	props := ToolFilesChecksumProperties{Tool_files_sha256: map[string]string{"tool.bin": "deadbeef"}}
+	contents := map[string][]byte{"tool.bin": []byte("tool binary contents")}
+	err := VerifyToolFilesChecksums(props, contents)
+	if err == nil {
+		t.Fatalf("expected a mismatch error")
+	}
+	if _, ok := err.(*ChecksumMismatchError); !ok {
+		t.Errorf("got error of type %T, want *ChecksumMismatchError", err)
+	}
+}
+
+func TestVerifyToolFilesChecksums_MissingEntry(t *testing.T) {
+	props := ToolFilesChecksumProperties{Tool_files_sha256: map[string]string{"missing.bin": "deadbeef"}}
+	if err := VerifyToolFilesChecksums(props, map[string][]byte{}); err == nil {
+		t.Errorf("expected an error for a missing tool_files entry")
+	}
+}
+
+func TestVerifyToolFilesChecksums_UnlistedFilesUnaffected(t *testing.T) {
+	props := ToolFilesChecksumProperties{}
+	contents := map[string][]byte{"other.bin": []byte("anything")}
+	if err := VerifyToolFilesChecksums(props, contents); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package genrule
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// VariantInvariantProperties holds the `variant_invariant` property for cc_genrule: when true, the
+// generation command is run once and its outputs are shared across all sanitizer variants of the
+// module instead of being regenerated identically for each one.
+type VariantInvariantProperties struct {
+	Variant_invariant *bool
+}
+
+// variantDependentExpansionRe matches genrule command substitutions whose expansion differs per
+// sanitizer variant, which would make sharing a single generation run across variants incorrect.
+var variantDependentExpansionRe = regexp.MustCompile(`\$\((sanitize|ccflags-sanitize|variant)\)`)
+
+// IsVariantInvariant reports whether the module opted into sharing one generation run across
+// sanitizer variants.
+func IsVariantInvariant(props VariantInvariantProperties) bool {
+	return props.Variant_invariant != nil && *props.Variant_invariant
+}
+
+// ValidateVariantInvariantCmd errors if variant_invariant is set but cmd references a
+// variant-dependent expansion, since collapsing the rule to one shared run would then produce
+// stale or wrong outputs for some variants.
+func ValidateVariantInvariantCmd(props VariantInvariantProperties, cmd string) error {
+	if !IsVariantInvariant(props) {
+		return nil
+	}
+	if m := variantDependentExpansionRe.FindString(cmd); m != "" {
+		return fmt.Errorf("variant_invariant: cmd contains variant-dependent expansion %q, so it cannot be shared across sanitizer variants", m)
+	}
+	return nil
+}
+
+// SharedVariantOutputs returns the outputs that every sanitizer variant should alias to, given
+// the outputs produced by the one shared generation run. It exists purely to name the aliasing
+// step clearly at call sites; the outputs themselves are unchanged.
+func SharedVariantOutputs(primaryVariantOutputs []string) []string {
+	return primaryVariantOutputs
+}
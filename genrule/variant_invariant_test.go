@@ -0,0 +1,34 @@
+package genrule
+
+import "testing"
+
+func boolPtrVariantInvariant(b bool) *bool { return &b }
+
+func TestIsVariantInvariant(t *testing.T) {
+	if IsVariantInvariant(VariantInvariantProperties{}) {
+		t.Errorf("expected false when unset")
+	}
+	if !IsVariantInvariant(VariantInvariantProperties{Variant_invariant: boolPtrVariantInvariant(true)}) {
+		t.Errorf("expected true when set")
+	}
+}
+
+func TestValidateVariantInvariantCmd_RejectsVariantDependentExpansion(t *testing.T) {
+	props := VariantInvariantProperties{Variant_invariant: boolPtrVariantInvariant(true)}
+	if err := ValidateVariantInvariantCmd(props, "gen --flags=$(sanitize)"); err == nil {
+		t.Errorf("expected an error for a variant-dependent expansion")
+	}
+}
+
+func TestValidateVariantInvariantCmd_AllowsInvariantCmd(t *testing.T) {
+	props := VariantInvariantProperties{Variant_invariant: boolPtrVariantInvariant(true)}
+	if err := ValidateVariantInvariantCmd(props, "gen --flags=$(in)"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateVariantInvariantCmd_NoopWhenNotInvariant(t *testing.T) {
+	if err := ValidateVariantInvariantCmd(VariantInvariantProperties{}, "gen --flags=$(sanitize)"); err != nil {
+		t.Errorf("unexpected error when variant_invariant unset: %v", err)
+	}
+}